@@ -2,6 +2,7 @@ package designer
 
 import (
 	"context"
+	"github.com/hashicorp/go-multierror"
 	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 	"net"
@@ -54,15 +55,30 @@ func (d AgentModeOptions) Command() string {
 	return COMMAND_DESIGNER
 }
 
+// Check проверяет параметры команды: должен быть указан ровно один из параметров
+// /AgentSSHHostKeyAuto и /AgentSSHHostKey, порт должен быть в диапазоне 0-65535
 func (d AgentModeOptions) Check() error {
 
+	var err multierror.Error
+
 	if !d.SSHHostKeyAuto && len(d.SSHHostKey) == 0 {
 
-		return errors.Check.New("ssh host key must be set").WithContext("msg", "field SSHHostKeyAuto or SSHHostKey not set")
+		multierror.Append(&err, errors.Check.New("ssh host key must be set").
+			WithContext("msg", "field SSHHostKeyAuto or SSHHostKey not set"))
+
+	}
+
+	if d.SSHHostKeyAuto && len(d.SSHHostKey) > 0 {
+		multierror.Append(&err, errors.Check.New("ssh host key auto and ssh host key are mutually exclusive").
+			WithContext("msg", "fields SSHHostKeyAuto and SSHHostKey set together"))
+	}
 
+	if d.Port < 0 || d.Port > 65535 {
+		multierror.Append(&err, errors.Check.Newf("agent port <%d> out of range", d.Port).
+			WithContext("msg", "field Port out of range"))
 	}
 
-	return nil
+	return err.ErrorOrNil()
 }
 
 func (d AgentModeOptions) Values() []string {
@@ -0,0 +1,309 @@
+// Пакет разбора файла служебных сообщений (/Out) команд /CheckConfig и /CheckModules
+package checklog
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SEVERITY_ERROR   Severity = "error"
+	SEVERITY_WARNING Severity = "warning"
+	SEVERITY_INFO    Severity = "info"
+)
+
+// Diagnostic сообщение проверки конфигурации
+type Diagnostic struct {
+	// Module полное имя модуля, например ОбщийМодуль.ОбщегоНазначения.Модуль.
+	// Не заполняется для сообщений, не относящихся к модулю
+	Module string `json:"module,omitempty"`
+
+	// Line номер строки модуля
+	Line int `json:"line,omitempty"`
+
+	// Column номер колонки модуля
+	Column int `json:"column,omitempty"`
+
+	Severity Severity `json:"severity"`
+
+	Message string `json:"message"`
+
+	// ObjectPath полное имя объекта метаданных, к которому относится сообщение
+	ObjectPath string `json:"object_path,omitempty"`
+
+	// Mode режим проверки, в котором обнаружена ошибка (Сервер, Тонкий клиент и т.д.)
+	Mode string `json:"mode,omitempty"`
+
+	// Source фрагмент исходного текста модуля с отметкой места ошибки <<?>>
+	Source string `json:"source,omitempty"`
+}
+
+var (
+	// {ОбщийМодуль.ОбщегоНазначения.Модуль(15,5)}: Переменная не определена (НеизвестнаяПеременная)
+	reModule = regexp.MustCompile(`^\{(.+)\((\d+),(\d+)\)\}:\s*(.*)$`)
+
+	// Справочник.Номенклатура.Форма.ФормаЭлемента.Форма Пустой обработчик: ПриОткрытии
+	reObject = regexp.MustCompile(`^([\p{L}_][\p{L}\p{N}_]*(?:\.[\p{L}_][\p{L}\p{N}_]*)+):?\s+(.+)$`)
+
+	// (Проверка: Сервер)
+	reMode = regexp.MustCompile(`\s*\((?:Проверка|Check):\s*([^)]+)\)\s*$`)
+
+	severityMarkers = map[string]Severity{
+		"[Ошибка]":         SEVERITY_ERROR,
+		"[Error]":          SEVERITY_ERROR,
+		"[Предупреждение]": SEVERITY_WARNING,
+		"[Warning]":        SEVERITY_WARNING,
+		"[Информация]":     SEVERITY_INFO,
+		"[Info]":           SEVERITY_INFO,
+	}
+
+	warningMessages = []string{
+		"Пустой обработчик",
+		"Неиспользуемая",
+		"не используется",
+		"Empty handler",
+		"Unused",
+		"is not used",
+	}
+
+	noErrorsMessages = []string{
+		"Ошибок не обнаружено",
+		"No errors found",
+	}
+
+	moduleNames = []string{
+		"Модуль",
+		"МодульОбъекта",
+		"МодульМенеджера",
+		"МодульНабораЗаписей",
+		"МодульМенеджераЗначения",
+		"МодульКоманды",
+		"МодульПриложения",
+		"МодульУправляемогоПриложения",
+		"МодульОбычногоПриложения",
+		"МодульСеанса",
+		"МодульВнешнегоСоединения",
+		"Форма",
+		"Module",
+		"ObjectModule",
+		"ManagerModule",
+		"RecordSetModule",
+		"ValueManagerModule",
+		"CommandModule",
+		"ApplicationModule",
+		"ManagedApplicationModule",
+		"OrdinaryApplicationModule",
+		"SessionModule",
+		"ExternalConnectionModule",
+		"Form",
+	}
+)
+
+// Parse разбирает текст файла служебных сообщений проверки конфигурации
+func Parse(r io.Reader) ([]Diagnostic, error) {
+
+	var diagnostics []Diagnostic
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	first := true
+
+	for scanner.Scan() {
+
+		text := scanner.Text()
+
+		if first {
+			text = strings.TrimPrefix(text, "\ufeff")
+			first = false
+		}
+
+		text = strings.TrimRight(text, "\r ")
+
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+
+		if isContinuation(text) && len(diagnostics) > 0 {
+			appendSource(&diagnostics[len(diagnostics)-1], strings.TrimSpace(text))
+			continue
+		}
+
+		if isNoErrors(text) {
+			continue
+		}
+
+		diagnostics = append(diagnostics, parseLine(strings.TrimSpace(text)))
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return diagnostics, nil
+}
+
+// ParseFile разбирает файл служебных сообщений проверки конфигурации
+func ParseFile(file string) ([]Diagnostic, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data))
+}
+
+func parseLine(text string) Diagnostic {
+
+	severity, text := cutSeverity(text)
+
+	if m := reModule.FindStringSubmatch(text); m != nil {
+
+		line, _ := strconv.Atoi(m[2])
+		column, _ := strconv.Atoi(m[3])
+		message, mode := cutMode(m[4])
+
+		d := Diagnostic{
+			Module:     m[1],
+			Line:       line,
+			Column:     column,
+			Message:    message,
+			ObjectPath: objectPath(m[1]),
+			Mode:       mode,
+			Severity:   severity,
+		}
+
+		if len(d.Severity) == 0 {
+			d.Severity = messageSeverity(message)
+		}
+
+		return d
+	}
+
+	d := Diagnostic{
+		Severity: severity,
+		Message:  text,
+	}
+
+	if m := reObject.FindStringSubmatch(text); m != nil {
+
+		d.ObjectPath = m[1]
+		d.Message = m[2]
+
+		if isModule(m[1]) {
+			d.Module = m[1]
+			d.ObjectPath = objectPath(m[1])
+		}
+
+	}
+
+	d.Message, d.Mode = cutMode(d.Message)
+
+	if len(d.Severity) == 0 {
+		d.Severity = messageSeverity(d.Message)
+	}
+
+	return d
+}
+
+func appendSource(d *Diagnostic, text string) {
+
+	source, mode := cutMode(text)
+
+	if len(mode) > 0 {
+		d.Mode = mode
+	}
+
+	if len(d.Source) > 0 {
+		d.Source += "\n"
+	}
+
+	d.Source += source
+
+}
+
+func isContinuation(text string) bool {
+	return strings.HasPrefix(text, "\t") || strings.HasPrefix(text, " ")
+}
+
+func isNoErrors(text string) bool {
+
+	for _, msg := range noErrorsMessages {
+		if strings.HasPrefix(strings.TrimSpace(text), msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cutSeverity(text string) (Severity, string) {
+
+	for marker, severity := range severityMarkers {
+		if strings.HasPrefix(text, marker) {
+			return severity, strings.TrimSpace(strings.TrimPrefix(text, marker))
+		}
+	}
+
+	return "", text
+}
+
+func cutMode(text string) (string, string) {
+
+	m := reMode.FindStringSubmatchIndex(text)
+
+	if m == nil {
+		return text, ""
+	}
+
+	return text[:m[0]], strings.TrimSpace(text[m[2]:m[3]])
+}
+
+func messageSeverity(message string) Severity {
+
+	for _, msg := range warningMessages {
+		if strings.Contains(message, msg) {
+			return SEVERITY_WARNING
+		}
+	}
+
+	return SEVERITY_ERROR
+}
+
+func isModule(name string) bool {
+
+	idx := strings.LastIndex(name, ".")
+
+	if idx == -1 {
+		return false
+	}
+
+	last := name[idx+1:]
+
+	for _, moduleName := range moduleNames {
+		if last == moduleName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func objectPath(module string) string {
+
+	if !isModule(module) {
+		return module
+	}
+
+	return module[:strings.LastIndex(module, ".")]
+}
@@ -0,0 +1,188 @@
+package checklog
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fixture(name string) string {
+	return filepath.Join("..", "tests", "fixtures", "checklog", name)
+}
+
+func TestParseFile(t *testing.T) {
+
+	tests := []struct {
+		name string
+		file string
+		want []Diagnostic
+	}{
+		{
+			"check config",
+			"checkconfig.txt",
+			[]Diagnostic{
+				{
+					Severity:   SEVERITY_ERROR,
+					Message:    "Неразрешимые ссылки на объекты метаданных (1)",
+					ObjectPath: "Справочник.Номенклатура.Реквизит.ЕдиницаИзмерения",
+				},
+				{
+					Module:     "ОбщийМодуль.ОбщегоНазначения.Модуль",
+					Line:       15,
+					Column:     5,
+					Severity:   SEVERITY_ERROR,
+					Message:    "Переменная не определена (НеизвестнаяПеременная)",
+					ObjectPath: "ОбщийМодуль.ОбщегоНазначения",
+					Mode:       "Сервер",
+					Source:     "<<?>>НеизвестнаяПеременная = 1;",
+				},
+				{
+					Module:     "Справочник.Номенклатура.Форма.ФормаЭлемента.Форма",
+					Line:       42,
+					Column:     3,
+					Severity:   SEVERITY_ERROR,
+					Message:    "Процедура или функция с указанным именем не определена (ОбновитьИтоги)",
+					ObjectPath: "Справочник.Номенклатура.Форма.ФормаЭлемента",
+					Mode:       "Тонкий клиент",
+					Source:     "<<?>>ОбновитьИтоги();",
+				},
+				{
+					Module:     "Документ.РеализацияТоваров.Форма.ФормаДокумента.Форма",
+					Severity:   SEVERITY_WARNING,
+					Message:    "Пустой обработчик: ПриОткрытии",
+					ObjectPath: "Документ.РеализацияТоваров.Форма.ФормаДокумента",
+				},
+				{
+					Module:     "Документ.РеализацияТоваров.Форма.ФормаДокумента.Форма",
+					Severity:   SEVERITY_ERROR,
+					Message:    "Не найден назначенный обработчик: ПередЗаписью",
+					ObjectPath: "Документ.РеализацияТоваров.Форма.ФормаДокумента",
+				},
+				{
+					Module:     "Конфигурация.МодульПриложения",
+					Severity:   SEVERITY_WARNING,
+					Message:    "Неиспользуемая процедура: СтараяПроцедура",
+					ObjectPath: "Конфигурация",
+				},
+			},
+		},
+		{
+			"check modules",
+			"checkmodules.txt",
+			[]Diagnostic{
+				{
+					Module:     "CommonModule.Common.Module",
+					Line:       3,
+					Column:     1,
+					Severity:   SEVERITY_ERROR,
+					Message:    "Expected operator",
+					ObjectPath: "CommonModule.Common",
+					Mode:       "Server",
+				},
+				{
+					Module:     "Catalog.Products.ObjectModule",
+					Line:       120,
+					Column:     12,
+					Severity:   SEVERITY_ERROR,
+					Message:    "Variable not defined (UnknownVariable)",
+					ObjectPath: "Catalog.Products",
+					Mode:       "Thin client",
+					Source:     "Result = <<?>>UnknownVariable;",
+				},
+				{
+					Module:     "Document.Sales.Form.DocumentForm.Form",
+					Line:       7,
+					Column:     9,
+					Severity:   SEVERITY_ERROR,
+					Message:    "Modal method call is not allowed (DoQueryBox)",
+					ObjectPath: "Document.Sales.Form.DocumentForm",
+					Mode:       "Thin client",
+					Source:     "<<?>>DoQueryBox(\"Continue?\", QuestionDialogMode.YesNo);",
+				},
+			},
+		},
+		{
+			"no errors",
+			"noerrors.txt",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFile(fixture(tt.file))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+
+	diagnostics, err := ParseFile(fixture("checkmodules.txt"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, diagnostics))
+
+	var got []Diagnostic
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, diagnostics, got)
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf, nil))
+	require.Equal(t, "[]\n", buf.String())
+}
+
+func TestWriteJUnit(t *testing.T) {
+
+	diagnostics, err := ParseFile(fixture("checkconfig.txt"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, "CheckConfig", diagnostics))
+
+	var got junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &got))
+
+	require.Equal(t, 6, got.Tests)
+	require.Equal(t, 4, got.Failures)
+	require.Len(t, got.Suites, 1)
+	require.Equal(t, "ОбщийМодуль.ОбщегоНазначения.Модуль(15,5)", got.Suites[0].TestCases[1].Name)
+	require.Equal(t, "ОбщийМодуль.ОбщегоНазначения", got.Suites[0].TestCases[1].ClassName)
+
+	buf.Reset()
+	require.NoError(t, WriteJUnit(&buf, "CheckConfig", nil))
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, 1, got.Tests)
+	require.Equal(t, 0, got.Failures)
+}
+
+func TestWriteSARIF(t *testing.T) {
+
+	diagnostics, err := ParseFile(fixture("checkmodules.txt"))
+	require.NoError(t, err)
+
+	resolver := func(d Diagnostic) string {
+		return strings.ReplaceAll(d.Module, ".", "/") + ".bsl"
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, "1cv8", diagnostics, resolver))
+
+	var got sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	require.Equal(t, "2.1.0", got.Version)
+	require.Len(t, got.Runs, 1)
+	require.Len(t, got.Runs[0].Results, 3)
+
+	result := got.Runs[0].Results[1]
+	require.Equal(t, "error", result.Level)
+	require.Equal(t, "Catalog/Products/ObjectModule.bsl", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, 120, result.Locations[0].PhysicalLocation.Region.StartLine)
+	require.Equal(t, "Catalog.Products.ObjectModule", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
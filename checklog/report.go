@@ -0,0 +1,280 @@
+package checklog
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// WriteJSON выводит сообщения проверки в формате JSON
+func WriteJSON(w io.Writer, diagnostics []Diagnostic) error {
+
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(diagnostics)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit выводит сообщения проверки в формате JUnit XML.
+// Каждое сообщение выводится отдельным тестом, ошибки выводятся как проваленные тесты.
+// Если сообщений нет, выводится один успешный тест с именем name.
+func WriteJUnit(w io.Writer, name string, diagnostics []Diagnostic) error {
+
+	suite := junitTestSuite{
+		Name: name,
+	}
+
+	for _, d := range diagnostics {
+
+		tc := junitTestCase{
+			Name:      d.location(),
+			ClassName: d.ObjectPath,
+		}
+
+		if len(tc.ClassName) == 0 {
+			tc.ClassName = name
+		}
+
+		switch d.Severity {
+		case SEVERITY_ERROR:
+			tc.Failure = &junitFailure{
+				Message: d.Message,
+				Type:    string(d.Severity),
+				Text:    d.details(),
+			}
+			suite.Failures++
+		default:
+			tc.SystemOut = d.details()
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      name,
+			ClassName: name,
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+
+	report := junitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// URIResolver возвращает путь к файлу исходного текста для сообщения проверки.
+// Пустая строка означает, что файл не определен.
+type URIResolver func(d Diagnostic) string
+
+// WriteSARIF выводит сообщения проверки в формате SARIF 2.1.0.
+// Если resolver не указан, физическое расположение сообщения не выводится,
+// и сообщение указывает только на объект метаданных.
+func WriteSARIF(w io.Writer, tool string, diagnostics []Diagnostic, resolver URIResolver) error {
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: tool}},
+		Results: []sarifResult{},
+	}
+
+	for _, d := range diagnostics {
+
+		result := sarifResult{
+			Level:   sarifLevel(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+		}
+
+		var location sarifLocation
+
+		if resolver != nil {
+			if uri := resolver(d); len(uri) > 0 {
+				location.PhysicalLocation = &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uri},
+				}
+				if d.Line > 0 {
+					location.PhysicalLocation.Region = &sarifRegion{
+						StartLine:   d.Line,
+						StartColumn: d.Column,
+					}
+				}
+			}
+		}
+
+		if len(d.Module) > 0 {
+			location.LogicalLocations = append(location.LogicalLocations,
+				sarifLogicalLocation{FullyQualifiedName: d.Module, Kind: "module"})
+		} else if len(d.ObjectPath) > 0 {
+			location.LogicalLocations = append(location.LogicalLocations,
+				sarifLogicalLocation{FullyQualifiedName: d.ObjectPath, Kind: "object"})
+		}
+
+		if location.PhysicalLocation != nil || len(location.LogicalLocations) > 0 {
+			result.Locations = append(result.Locations, location)
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(log)
+}
+
+func sarifLevel(severity Severity) string {
+
+	switch severity {
+	case SEVERITY_ERROR:
+		return "error"
+	case SEVERITY_WARNING:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func (d Diagnostic) location() string {
+
+	switch {
+	case len(d.Module) > 0 && d.Line > 0:
+		return fmt.Sprintf("%s(%d,%d)", d.Module, d.Line, d.Column)
+	case len(d.Module) > 0:
+		return d.Module
+	case len(d.ObjectPath) > 0:
+		return d.ObjectPath
+	default:
+		return d.Message
+	}
+}
+
+func (d Diagnostic) details() string {
+
+	text := d.location() + ": " + d.Message
+
+	if len(d.Mode) > 0 {
+		text += " (" + d.Mode + ")"
+	}
+
+	if len(d.Source) > 0 {
+		text += "\n" + d.Source
+	}
+
+	return text
+}
@@ -0,0 +1,226 @@
+package designer
+
+import (
+	stderrors "errors"
+	"github.com/v8platform/errors"
+	"strings"
+)
+
+// DesignerError известная ошибка выполнения конфигуратора.
+// Используется как значение для сравнения через errors.Is
+type DesignerError struct {
+	msg  string
+	kind errors.Kind
+}
+
+func (e *DesignerError) Error() string {
+	return e.msg
+}
+
+// Kind вид ошибки github.com/v8platform/errors
+func (e *DesignerError) Kind() errors.Kind {
+	return e.kind
+}
+
+var (
+	// ErrInfobaseLocked информационная база заблокирована другими сеансами
+	// или начало сеанса запрещено блокировкой сеансов
+	ErrInfobaseLocked = &DesignerError{"infobase locked", errors.Transient}
+
+	// ErrObjectLocked объект хранилища захвачен другим пользователем
+	ErrObjectLocked = &DesignerError{"repository object locked by another user", errors.Transient}
+
+	// ErrRepositoryLocked хранилище конфигурации заблокировано другой операцией
+	ErrRepositoryLocked = &DesignerError{"repository locked", errors.Transient}
+
+	// ErrRepositoryAuth неверное имя пользователя или пароль хранилища конфигурации
+	ErrRepositoryAuth = &DesignerError{"repository authentication failed", errors.Permission}
+
+	// ErrInfobaseAuth неверное имя пользователя или пароль информационной базы
+	ErrInfobaseAuth = &DesignerError{"infobase authentication failed", errors.Permission}
+
+	// ErrNoLicense не обнаружена лицензия
+	ErrNoLicense = &DesignerError{"license not found", errors.NotExist}
+
+	// ErrVersionMismatch версия платформы не соответствует версии сервера или хранилища
+	ErrVersionMismatch = &DesignerError{"platform version mismatch", errors.Invalid}
+
+	// ErrInfobaseNotFound информационная база не обнаружена
+	ErrInfobaseNotFound = &DesignerError{"infobase not found", errors.NotExist}
+)
+
+// classifyRules правила классификации сообщений конфигуратора.
+// Сообщение соответствует правилу, если содержит все подстроки одного из шаблонов.
+// Правила проверяются по порядку: более частные правила должны идти раньше общих
+var classifyRules = []struct {
+	err      *DesignerError
+	patterns [][]string
+}{
+	{ErrObjectLocked, [][]string{
+		{"захвачен", "пользовател"},
+		{"захвачен", "другим"},
+		{"locked by another user"},
+		{"captured by"},
+		{"already locked by"},
+	}},
+	{ErrRepositoryLocked, [][]string{
+		{"хранилище", "заблокировано"},
+		{"repository is locked"},
+	}},
+	{ErrRepositoryAuth, [][]string{
+		{"хранилищ", "пароль"},
+		{"хранилищ", "аутентификац"},
+		{"хранилищ", "идентификац"},
+		{"repository", "password"},
+		{"repository", "authentication"},
+	}},
+	{ErrInfobaseAuth, [][]string{
+		{"идентификация пользователя не выполнена"},
+		{"неправильное имя пользователя или пароль"},
+		{"неверное имя пользователя или пароль"},
+		{"user authentication failed"},
+		{"invalid user name or password"},
+	}},
+	{ErrNoLicense, [][]string{
+		{"не обнаружена лицензия"},
+		{"лицензия не обнаружена"},
+		{"не найдена лицензия"},
+		{"license not found"},
+		{"no license"},
+	}},
+	{ErrVersionMismatch, [][]string{
+		{"версия клиентского приложения", "не соответствует версии сервера"},
+		{"несоответствие версий клиента и сервера"},
+		{"client application version", "does not match server version"},
+		{"client and server versions mismatch"},
+	}},
+	{ErrInfobaseLocked, [][]string{
+		{"ошибка блокировки информационной базы"},
+		{"невозможно установить монопольный режим"},
+		{"начало сеанса с информационной базой запрещено"},
+		{"информационная база заблокирована"},
+		{"error locking the infobase"},
+		{"cannot set exclusive mode"},
+		{"session start", "prohibited"},
+		{"start of session", "prohibited"},
+		{"infobase is locked"},
+	}},
+	{ErrInfobaseNotFound, [][]string{
+		{"информационная база не обнаружена"},
+		{"файл базы данных не обнаружен"},
+		{"infobase not found"},
+		{"database file not found"},
+	}},
+}
+
+// ClassifiedError ошибка, сопоставленная известной ошибке конфигуратора.
+// errors.Is(err, Err...) возвращает true для соответствующей известной ошибки
+type ClassifiedError struct {
+	// Known известная ошибка, например ErrInfobaseLocked
+	Known *DesignerError
+
+	// Message сообщение конфигуратора, по которому определена ошибка
+	Message string
+
+	// Err исходная ошибка
+	Err error
+}
+
+func (e *ClassifiedError) Error() string {
+
+	if e.Err == nil {
+		return e.Known.Error() + ": " + e.Message
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ClassifiedError) Is(target error) bool {
+	return target == error(e.Known)
+}
+
+// ClassifyMessage возвращает известную ошибку, соответствующую сообщению конфигуратора.
+// Сообщения сравниваются без учета регистра на русском и английском языках
+func ClassifyMessage(message string) (*DesignerError, bool) {
+
+	text := strings.ToLower(message)
+
+	for _, rule := range classifyRules {
+		for _, pattern := range rule.patterns {
+			if containsAll(text, pattern) {
+				return rule.err, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Classify сопоставляет ошибку err известной ошибке по сообщениям конфигуратора messages
+// (например, строкам файла /Out) и тексту самой ошибки.
+// Если известная ошибка не найдена или ошибка уже классифицирована, возвращается исходная ошибка
+func Classify(err error, messages ...string) error {
+
+	var classified *ClassifiedError
+
+	if err == nil || stderrors.As(err, &classified) {
+		return err
+	}
+
+	texts := append(append([]string{}, messages...), err.Error())
+
+	for _, message := range texts {
+
+		if known, ok := ClassifyMessage(message); ok {
+			return &ClassifiedError{
+				Known:   known,
+				Message: strings.TrimSpace(message),
+				Err:     err,
+			}
+		}
+
+	}
+
+	return err
+}
+
+// ErrorKind возвращает вид ошибки github.com/v8platform/errors,
+// в том числе для ошибок, обернутых в ClassifiedError.
+// Если исходная ошибка не содержит вида, возвращается вид известной ошибки
+func ErrorKind(err error) errors.Kind {
+
+	var known *DesignerError
+
+	for e := err; e != nil; e = stderrors.Unwrap(e) {
+
+		if _, ok := e.(errors.Error); ok {
+			return errors.GetType(e)
+		}
+
+		if classified, ok := e.(*ClassifiedError); ok && known == nil {
+			known = classified.Known
+		}
+
+	}
+
+	if known != nil {
+		return known.Kind()
+	}
+
+	return errors.GetType(err)
+}
+
+func containsAll(s string, substrings []string) bool {
+
+	for _, sub := range substrings {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+
+	return true
+}
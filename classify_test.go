@@ -0,0 +1,115 @@
+package designer
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"github.com/v8platform/errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var knownErrors = map[string]*DesignerError{
+	"ErrInfobaseLocked":   ErrInfobaseLocked,
+	"ErrObjectLocked":     ErrObjectLocked,
+	"ErrRepositoryLocked": ErrRepositoryLocked,
+	"ErrRepositoryAuth":   ErrRepositoryAuth,
+	"ErrInfobaseAuth":     ErrInfobaseAuth,
+	"ErrNoLicense":        ErrNoLicense,
+	"ErrVersionMismatch":  ErrVersionMismatch,
+	"ErrInfobaseNotFound": ErrInfobaseNotFound,
+}
+
+func TestClassifyMessage(t *testing.T) {
+
+	data, err := ioutil.ReadFile(filepath.Join("tests", "fixtures", "messages", "classify.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fixtures []struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range fixtures {
+		t.Run(tt.Message, func(t *testing.T) {
+
+			got, ok := ClassifyMessage(tt.Message)
+
+			if len(tt.Error) == 0 {
+				if ok {
+					t.Errorf("ClassifyMessage() = %v, want no match", got)
+				}
+				return
+			}
+
+			if want := knownErrors[tt.Error]; got != want {
+				t.Errorf("ClassifyMessage() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+
+	base := errors.Runtime.New("designer exit code 1")
+	messages := []string{"Ошибка обновления конфигурации", "Ошибка блокировки информационной базы для конфигурирования"}
+
+	err := Classify(base, messages...)
+
+	if !stderrors.Is(err, ErrInfobaseLocked) {
+		t.Errorf("Classify() = %v, want ErrInfobaseLocked", err)
+	}
+
+	if stderrors.Is(err, ErrObjectLocked) {
+		t.Errorf("Classify() = %v, unexpected ErrObjectLocked", err)
+	}
+
+	if kind := ErrorKind(err); kind != errors.Runtime {
+		t.Errorf("ErrorKind() = %v, want %v", kind, errors.Runtime)
+	}
+
+	if err.Error() != base.Error() {
+		t.Errorf("Error() = %v, want %v", err.Error(), base.Error())
+	}
+
+	err = Classify(stderrors.New("exit status 1: License not found"))
+
+	if !stderrors.Is(err, ErrNoLicense) {
+		t.Errorf("Classify() = %v, want ErrNoLicense", err)
+	}
+
+	if kind := ErrorKind(err); kind != errors.NotExist {
+		t.Errorf("ErrorKind() = %v, want %v", kind, errors.NotExist)
+	}
+
+	if err := Classify(base, "Неизвестная ошибка"); err != error(base) {
+		t.Errorf("Classify() = %v, want unchanged error", err)
+	}
+
+	if err := Classify(nil, messages...); err != nil {
+		t.Errorf("Classify() = %v, want nil", err)
+	}
+
+	result := Result{Code: DUMP_RESULT_ERROR, Messages: messages[1:]}
+
+	err = result.Err()
+
+	if !stderrors.Is(err, ErrInfobaseLocked) || ErrorKind(err) != errors.Transient {
+		t.Errorf("Result.Err() = %v, want ErrInfobaseLocked with kind %v", err, errors.Transient)
+	}
+
+	if !IsRetryable(err) || Classify(err, result.Messages...) != err {
+		t.Errorf("IsRetryable(%v) = false, want true without double classification", err)
+	}
+
+	if err := (Result{Code: DUMP_RESULT_ERROR, Messages: []string{"Неизвестная ошибка"}}).Err(); stderrors.Is(err, ErrInfobaseLocked) {
+		t.Errorf("Result.Err() = %v, unexpected ErrInfobaseLocked", err)
+	}
+
+}
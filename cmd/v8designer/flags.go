@@ -0,0 +1,291 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// flattenTypes встроенные структуры с общими параметрами запуска,
+// параметры которых не получают префикс
+var flattenTypes = map[string]bool{
+	"Designer":              true,
+	"CreateInfoBaseOptions": true,
+}
+
+// fieldUsages описания флагов для полей, которые не передаются как параметры команды (тег v8:"-")
+var fieldUsages = map[string]string{
+	"Out":        "файл вывода служебных сообщений, /Out",
+	"NoTruncate": "не очищать файл вывода служебных сообщений перед запуском, /Out -NoTruncate",
+	"DumpResult": "файл результата запуска, /DumpResult",
+	"AccessCode": "код доступа при установленной блокировке сеансов, /UC (аналог --uc)",
+}
+
+// fieldFlag флаг командной строки для поля структуры параметров команды
+type fieldFlag struct {
+	root reflect.Value
+	path []int
+	kind reflect.Kind
+}
+
+func (f *fieldFlag) field(alloc bool) (reflect.Value, bool) {
+
+	v := f.root.Elem()
+
+	for _, i := range f.path {
+
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v, true
+}
+
+func (f *fieldFlag) String() string {
+
+	if !f.root.IsValid() {
+		return ""
+	}
+
+	v, ok := f.field(false)
+
+	// нулевые значения не выводятся в справке как значения по умолчанию
+	if !ok || v.IsZero() {
+		return ""
+	}
+
+	switch f.kind {
+	case reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func (f *fieldFlag) Set(s string) error {
+
+	v, _ := f.field(true)
+
+	switch f.kind {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Slice:
+		var values []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); len(item) > 0 {
+				values = append(values, item)
+			}
+		}
+		v.Set(reflect.ValueOf(values).Convert(v.Type()))
+	}
+
+	return nil
+}
+
+func (f *fieldFlag) IsBoolFlag() bool {
+	return f.kind == reflect.Bool
+}
+
+// defineFlags определяет флаги для полей структуры параметров команды what (указатель на структуру).
+// Имя флага формируется из тега json, описание — из тега v8.
+// Поля вложенных структур получают префикс по тегу json вложенной структуры
+func defineFlags(fs *flag.FlagSet, what interface{}) {
+
+	root := reflect.ValueOf(what)
+	defineStructFlags(fs, root, root.Elem().Type(), nil, "")
+}
+
+func defineStructFlags(fs *flag.FlagSet, root reflect.Value, t reflect.Type, path []int, prefix string) {
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+
+		if len(field.PkgPath) > 0 && !field.Anonymous {
+			continue
+		}
+
+		name := jsonName(field)
+
+		if name == "-" {
+			continue
+		}
+
+		fieldPath := append(append([]int{}, path...), i)
+		fieldType := field.Type
+
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct {
+
+			nestedPrefix := prefix + name + "-"
+
+			if field.Anonymous && flattenTypes[fieldType.Name()] {
+				nestedPrefix = prefix
+			}
+
+			defineStructFlags(fs, root, fieldType, fieldPath, nestedPrefix)
+			continue
+		}
+
+		switch fieldType.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		case reflect.Slice:
+			if fieldType.Elem().Kind() != reflect.String {
+				continue
+			}
+		default:
+			continue
+		}
+
+		flagName := prefix + name
+
+		if fs.Lookup(flagName) != nil {
+			continue
+		}
+
+		value := &fieldFlag{root: root, path: fieldPath, kind: fieldType.Kind()}
+
+		fs.Var(value, flagName, flagUsage(field))
+
+	}
+
+}
+
+func jsonName(field reflect.StructField) string {
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+	if len(name) == 0 {
+		name = kebabCase(field.Name)
+	}
+
+	return strings.Replace(name, "_", "-", -1)
+}
+
+func flagUsage(field reflect.StructField) string {
+
+	v8 := strings.TrimSpace(strings.Split(field.Tag.Get("v8"), ",")[0])
+
+	if len(v8) == 0 || v8 == "-" {
+		if usage, ok := fieldUsages[field.Name]; ok {
+			return usage
+		}
+		return field.Name
+	}
+
+	return v8
+}
+
+// applyEnv устанавливает флагам, не указанным явно, значения переменных окружения:
+// env — имена переменных для общих флагов, для флагов с именем пользователя или паролем — V8_<ИМЯ_ФЛАГА>.
+// Вызывается после разбора флагов, чтобы значения из окружения не выводились в справке
+func applyEnv(fs *flag.FlagSet, env map[string]string) error {
+
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	var err error
+
+	fs.VisitAll(func(f *flag.Flag) {
+
+		if err != nil || explicit[f.Name] {
+			return
+		}
+
+		name, ok := env[f.Name]
+
+		if !ok && isCredential(f.Name) {
+			name, ok = envName(f.Name), true
+		}
+
+		if !ok {
+			return
+		}
+
+		if value, ok := os.LookupEnv(name); ok {
+			if setErr := f.Value.Set(value); setErr != nil {
+				err = fmt.Errorf("invalid value of %s for flag -%s: %v", name, f.Name, setErr)
+			}
+		}
+
+	})
+
+	return err
+}
+
+// isCredential флаг с именем пользователя или паролем, значение которого можно задать переменной окружения
+func isCredential(name string) bool {
+
+	for _, suffix := range []string{"user", "password", "pwd"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// envName возвращает имя переменной окружения для флага: V8_ и имя флага в верхнем регистре,
+// например repository-password -> V8_REPOSITORY_PASSWORD
+func envName(flagName string) string {
+	return "V8_" + strings.ToUpper(strings.Replace(flagName, "-", "_", -1))
+}
+
+// kebabCase преобразует имя в нижний регистр через дефис:
+// UpdateDBCfg -> update-db-cfg, IBRestoreIntegrity -> ib-restore-integrity
+func kebabCase(name string) string {
+
+	runes := []rune(name)
+
+	var b strings.Builder
+
+	for i, r := range runes {
+
+		if i > 0 && unicode.IsUpper(r) {
+
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if prevLower || nextLower && unicode.IsUpper(runes[i-1]) {
+				b.WriteRune('-')
+			}
+
+		}
+
+		b.WriteRune(unicode.ToLower(r))
+
+	}
+
+	return b.String()
+}
@@ -0,0 +1,225 @@
+// Команда v8designer запускает команды конфигуратора 1С:Предприятия.
+//
+// Подкоманды и их флаги формируются по тегам v8 и json структур параметров пакета designer:
+//
+//	v8designer load-cfg --ib 'File="./ib"' --file ./1cv8.cf
+//	v8designer dump-config-to-files --ib 'File="./ib"' --dir ./src
+//	v8designer repo update-cfg --ib 'File="./ib"' --repository-path ./repo --repository-user admin
+//	v8designer agent start --ib 'File="./ib"' --port 1543
+//
+// Строка соединения, код доступа и версия платформы могут быть заданы переменными окружения
+// V8_IB, V8_UC и V8_VERSION, имена пользователей и пароли — переменными V8_<ИМЯ_ФЛАГА>,
+// например V8_IB_USER, V8_IB_PASSWORD, V8_REPOSITORY_PASSWORD. Явно указанный флаг имеет приоритет.
+// Флаг --print-args выводит параметры запуска 1cv8 без выполнения команды, пароли скрываются.
+// Параметры /Out и /DumpResult, которые добавляются при запуске, не выводятся.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/v8platform/designer"
+	"github.com/v8platform/runner"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+const GROUP_REPOSITORY = "repo"
+
+// envFlags переменные окружения общих флагов
+var envFlags = map[string]string{
+	"ib":         "V8_IB",
+	"uc":         "V8_UC",
+	"v8-version": "V8_VERSION",
+}
+
+// aliases подкоманды, имя которых не выводится из имени команды
+var aliases = map[string]string{
+	"AgentMode": "agent start",
+}
+
+// subcommands возвращает соответствие имен подкоманд именам команд реестра
+func subcommands() map[string]string {
+
+	commands := make(map[string]string)
+
+	for _, name := range designer.Commands() {
+		commands[subcommandName(name)] = name
+	}
+
+	return commands
+}
+
+// subcommandName возвращает имя подкоманды для команды реестра:
+// LoadCfg -> load-cfg, RepositoryUpdateCfg -> repo update-cfg
+func subcommandName(name string) string {
+
+	if alias, ok := aliases[name]; ok {
+		return alias
+	}
+
+	if strings.HasPrefix(name, "Repository") {
+		return GROUP_REPOSITORY + " " + kebabCase(strings.TrimPrefix(name, "Repository"))
+	}
+
+	return kebabCase(name)
+}
+
+func main() {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		os.Exit(1)
+	}
+
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+
+	commands := subcommands()
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printCommands(stdout, commands)
+		return nil
+	}
+
+	name, args := args[0], args[1:]
+
+	if _, ok := commands[name]; !ok && len(args) > 0 {
+		name, args = name+" "+args[0], args[1:]
+	}
+
+	commandName, ok := commands[name]
+
+	if !ok {
+		printCommands(stderr, commands)
+		return fmt.Errorf("unknown command <%s>", name)
+	}
+
+	what, _ := designer.NewCommand(commandName)
+
+	fs := flag.NewFlagSet("v8designer "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		connect    = fs.String("ib", "", "строка соединения с информационной базой (V8_IB)")
+		user       = fs.String("ib-user", "", "пользователь информационной базы (V8_IB_USER)")
+		password   = fs.String("ib-password", "", "пароль пользователя информационной базы (V8_IB_PASSWORD)")
+		unlockCode = fs.String("uc", "", "код доступа при установленной блокировке сеансов, /UC (V8_UC)")
+		version    = fs.String("v8-version", "", "версия платформы (V8_VERSION)")
+		printArgs  = fs.Bool("print-args", false, "вывести параметры запуска без выполнения команды "+
+			"(без /Out и /DumpResult, которые добавляются при запуске)")
+	)
+
+	defineFlags(fs, what)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := applyEnv(fs, envFlags); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", fs.Args())
+	}
+
+	job := designer.Job{
+		Name:     name,
+		User:     *user,
+		Password: *password,
+		Command:  what,
+	}
+
+	if len(*connect) > 0 {
+
+		ib, err := designer.ParseConnectionString(*connect)
+
+		if err != nil {
+			return err
+		}
+
+		job.Infobase = ib
+	}
+
+	if err := job.Check(); err != nil {
+		return err
+	}
+
+	if *printArgs {
+		fmt.Fprintln(stdout, strings.Join(commandArgs(job, *unlockCode), "\n"))
+		return nil
+	}
+
+	opts := []interface{}{runner.WithUC(*unlockCode)}
+
+	if len(*version) > 0 {
+		opts = append(opts, runner.WithVersion(*version))
+	}
+
+	return job.Execute(ctx, opts...)
+}
+
+// commandArgs возвращает параметры запуска 1cv8 для задания, пароли и код доступа скрываются
+func commandArgs(job designer.Job, unlockCode string) []string {
+
+	args := []string{job.Command.Command()}
+
+	if job.Infobase != nil && job.Command.Command() != designer.COMMAND_CREATEINFOBASE {
+		args = append(args, job.Infobase.ConnectionString())
+	}
+
+	args = append(args, job.Command.Values()...)
+
+	if len(job.User) > 0 {
+		args = append(args, "/N "+job.User)
+		if len(job.Password) > 0 {
+			args = append(args, "/P "+job.Password)
+		}
+	}
+
+	if len(unlockCode) > 0 {
+		args = append(args, "/UC "+unlockCode)
+	}
+
+	return designer.MaskValues(args)
+}
+
+func printCommands(w io.Writer, commands map[string]string) {
+
+	names := make([]string, 0, len(commands))
+
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: v8designer <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-36s %s\n", name, commands[name])
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Use \"v8designer <command> -h\" for command flags.")
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"github.com/v8platform/designer"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSubcommandName(t *testing.T) {
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"LoadCfg", "load-cfg"},
+		{"DumpConfigToFiles", "dump-config-to-files"},
+		{"UpdateDBCfg", "update-db-cfg"},
+		{"IBRestoreIntegrity", "ib-restore-integrity"},
+		{"RepositoryUpdateCfg", "repo update-cfg"},
+		{"RepositoryAddUser", "repo add-user"},
+		{"AgentMode", "agent start"},
+	}
+	for _, tt := range tests {
+		if got := subcommandName(tt.name); got != tt.want {
+			t.Errorf("subcommandName(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRun_PrintArgs(t *testing.T) {
+
+	_ = os.Setenv("V8_REPOSITORY_PASSWORD", "secret")
+	defer os.Unsetenv("V8_REPOSITORY_PASSWORD")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			"load-cfg",
+			[]string{"load-cfg", "--ib", `File="./ib"`, "--ib-user", "admin", "--ib-password", "pwd",
+				"--file", "./1cv8.cf", "--update-db-cfg-server", "--print-args"},
+			[]string{
+				"DESIGNER",
+				`/IBConnectionString File="./ib";`,
+				"/DisableStartupDialogs",
+				"/DisableStartupMessages",
+				"/LoadCfg ./1cv8.cf",
+				"/UpdateDBCfg",
+				"-Server",
+				"/N admin",
+				"/P " + designer.PASSWORD_MASK,
+			},
+			false,
+		},
+		{
+			"repo add-user",
+			[]string{"repo", "add-user", "--ib", `File="./ib"`, "--repository-path", "./repo",
+				"--repository-user", "admin", "--user", "dev", "--rights", "LockObjects", "--print-args"},
+			[]string{
+				"DESIGNER",
+				`/IBConnectionString File="./ib";`,
+				"/DisableStartupDialogs",
+				"/DisableStartupMessages",
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryP " + designer.PASSWORD_MASK,
+				"/ConfigurationRepositoryAddUser",
+				"-User dev",
+				"-Rights LockObjects",
+			},
+			false,
+		},
+		{"unknown command", []string{"load-all"}, nil, true},
+		{"unknown flag", []string{"load-cfg", "--files", "./1cv8.cf"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var stdout, stderr bytes.Buffer
+
+			err := run(context.Background(), tt.args, &stdout, &stderr)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := strings.Split(strings.TrimSpace(stdout.String()), "\n"); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("run() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Env(t *testing.T) {
+
+	env := map[string]string{
+		"V8_IB":          `File="./env-ib"`,
+		"V8_IB_USER":     "env-user",
+		"V8_IB_PASSWORD": "env-secret",
+		"V8_UC":          "env-code",
+	}
+
+	for key, value := range env {
+		_ = os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	if err := run(context.Background(), []string{"load-cfg", "-h"}, &stdout, &stderr); err != flag.ErrHelp {
+		t.Fatalf("run() error = %v, want %v", err, flag.ErrHelp)
+	}
+
+	for _, value := range env {
+		if strings.Contains(stderr.String(), value) {
+			t.Errorf("run() help contains %q:\n%s", value, stderr.String())
+		}
+	}
+
+	stdout.Reset()
+
+	args := []string{"load-cfg", "--ib-user", "admin", "--file", "./1cv8.cf", "--print-args"}
+
+	if err := run(context.Background(), args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	want := []string{
+		"DESIGNER",
+		`/IBConnectionString File="./env-ib";`,
+		"/DisableStartupDialogs",
+		"/DisableStartupMessages",
+		"/LoadCfg ./1cv8.cf",
+		"/N admin",
+		"/P " + designer.PASSWORD_MASK,
+		"/UC " + designer.PASSWORD_MASK,
+	}
+
+	if got := strings.Split(strings.TrimSpace(stdout.String()), "\n"); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("run() = %q, want %q", got, want)
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+
+	if err := run(context.Background(), []string{"load-cfg", "-h"}, &stdout, &stderr); err != flag.ErrHelp {
+		t.Fatalf("run() error = %v, want %v", err, flag.ErrHelp)
+	}
+
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.TrimSpace(line) == "-" {
+			t.Fatalf("run() usage = %q, flag without description", stderr.String())
+		}
+	}
+
+	for _, want := range []string{"-dump-result", "файл результата запуска, /DumpResult", "без /Out и /DumpResult"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("run() usage = %q, want %q", stderr.String(), want)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
+	"github.com/v8platform/runner"
+	"strings"
 )
 
 var _ command = (*Designer)(nil)
@@ -10,14 +14,35 @@ type Designer struct {
 	DisableStartupDialogs  bool `v8:"/DisableStartupDialogs" json:"disable_startup_dialogs"`
 	DisableStartupMessages bool `v8:"/DisableStartupMessages" json:"disable_startup_messages"`
 	Visible                bool `v8:"/Visible" json:"visible"`
+
+	//— /Out <имя файла> — файл для вывода служебных сообщений.
+	// Параметры /Out и /DumpResult всегда добавляет runner, поэтому они не входят в Values(),
+	// а передаются опциями запуска, см. RunOptions
+	Out string `v8:"-" json:"out"`
+
+	//— -NoTruncate — не очищать файл /Out перед выводом сообщений
+	NoTruncate bool `v8:"-" json:"no_truncate"`
+
+	//— /DumpResult <имя файла> — файл для записи кода результата работы конфигуратора
+	DumpResult string `v8:"-" json:"dump_result"`
+
+	//— /UC <код доступа> — код разрешения запуска при установленной блокировке сеансов.
+	// Передается опцией запуска runner.WithUC, см. RunOptions
+	AccessCode string `v8:"-" json:"access_code"`
 }
 
 func (d Designer) Command() string {
 	return COMMAND_DESIGNER
 }
 
+// Check проверяет общие параметры запуска: -NoTruncate используется только с /Out
 func (d Designer) Check() error {
 
+	if d.NoTruncate && len(d.Out) == 0 {
+		return errors.Check.New("out file must be set").
+			WithContext("msg", "field NoTruncate requires field Out")
+	}
+
 	return nil
 }
 
@@ -27,6 +52,39 @@ func (d Designer) Values() []string {
 
 }
 
+// RunOptions возвращает опции запуска runner для файлов /Out, /DumpResult и кода доступа /UC.
+//
+// Пример:
+//	err := runner.Run(ib, what, what.RunOptions()...)
+//	result, _ := what.Result()
+func (d Designer) RunOptions() []interface{} {
+
+	var opts []interface{}
+
+	if len(d.Out) > 0 {
+		opts = append(opts, runner.WithOut(d.Out, d.NoTruncate))
+	}
+
+	if len(d.DumpResult) > 0 {
+		opts = append(opts, runner.WithDumpResult(d.DumpResult))
+	}
+
+	if len(d.AccessCode) > 0 {
+		opts = append(opts, runner.WithUC(d.AccessCode))
+	}
+
+	return opts
+}
+
+// Result читает результат выполнения команды из файлов /Out и /DumpResult
+func (d Designer) Result() (Result, error) {
+	return ReadResult(d.Out, d.DumpResult)
+}
+
+func (d Designer) resultFiles() (out string, noTruncate bool, dumpResult string) {
+	return d.Out, d.NoTruncate, d.DumpResult
+}
+
 func NewDesigner() Designer {
 
 	d := Designer{
@@ -74,6 +132,33 @@ func (o UpdateCfgOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: файл обновления обязателен,
+// -IncludeObjectsByUnresolvedRefs и -ClearUnresolvedRefs взаимоисключающие.
+// Также проверяются параметры обновления конфигурации базы данных, если они заданы
+func (o UpdateCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.File) == 0 {
+		multierror.Append(&err, errors.Check.New("update file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	if o.IncludeObjectsByUnresolvedRefs && o.ClearUnresolvedRefs {
+		multierror.Append(&err, errors.Check.New("include objects and clear unresolved refs are mutually exclusive").
+			WithContext("msg", "fields IncludeObjectsByUnresolvedRefs and ClearUnresolvedRefs set together"))
+	}
+
+	if o.UpdateDBCfg != nil {
+		multierror.Append(&err, o.UpdateDBCfg.Check())
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o UpdateCfgOptions) WithUpdateDBCfg(upd UpdateDBCfgOptions) UpdateCfgOptions {
 
 	UpdateDBCfg := &upd
@@ -127,7 +212,7 @@ type UpdateDBCfgOptions struct {
 	// Сначала выполняется попытка динамического обновления, если она завершена неудачно,
 	// будет запущено фоновое обновление.
 	//-Dynamic–  — Динамическое обновление запрещено.
-	BackgroundStart bool `v8:"-BackgroundCancel" json:"background_start"`
+	BackgroundStart bool `v8:"-BackgroundStart" json:"background_start"`
 
 	//-BackgroundCancel — отменяет запущенное фоновое обновление конфигурации базы данных.
 	// Если фоновое обновление не запущено, будет выдана ошибка.
@@ -178,6 +263,41 @@ func (d UpdateDBCfgOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: режимы фонового обновления
+// -BackgroundStart, -BackgroundCancel, -BackgroundFinish, -BackgroundResume и -BackgroundSuspend
+// взаимоисключающие
+func (d UpdateDBCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	var background []string
+
+	for _, mode := range []struct {
+		name string
+		set  bool
+	}{
+		{"BackgroundStart", d.BackgroundStart},
+		{"BackgroundCancel", d.BackgroundCancel},
+		{"BackgroundFinish", d.BackgroundFinish},
+		{"BackgroundResume", d.BackgroundResume},
+		{"BackgroundSuspend", d.BackgroundSuspend},
+	} {
+		if mode.set {
+			background = append(background, mode.name)
+		}
+	}
+
+	if len(background) > 1 {
+		multierror.Append(&err, errors.Check.New("background update modes are mutually exclusive").
+			WithContext("msg", "fields "+strings.Join(background, ", ")+" set together"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (d UpdateDBCfgOptions) WithExtension(extension string) UpdateDBCfgOptions {
 
 	return UpdateDBCfgOptions{
@@ -194,3 +314,408 @@ func (d UpdateDBCfgOptions) WithExtension(extension string) UpdateDBCfgOptions {
 	}
 
 }
+
+///CheckCanApplyConfigurationExtensions [-Extension <имя расширения>] [-AllZones | -Z <разделители>]
+//— проверка применимости расширений к текущей конфигурации информационной базы.
+//Код возврата 0 — все расширения могут быть применены, 1 — обнаружены ошибки применения.
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /CheckCanApplyConfigurationExtensions -Extension ИсправлениеОшибок -AllZones
+type CheckCanApplyExtensionsOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/CheckCanApplyConfigurationExtensions" json:"-"`
+
+	//-Extension <имя расширения> — проверка применимости указанного расширения.
+	// Если не указано, проверяются все расширения.
+	Extension string `v8:"-Extension, optional" json:"extension"`
+
+	//-AllZones — проверка выполняется для всех областей данных.
+	AllZones bool `v8:"-AllZones, optional" json:"all_zones"`
+
+	//-Z <разделители> — проверка выполняется для указанных значений разделителей.
+	Zones string `v8:"-Z, optional" json:"zones"`
+}
+
+func (o CheckCanApplyExtensionsOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: -AllZones и -Z взаимоисключающие
+func (o CheckCanApplyExtensionsOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if o.AllZones && len(o.Zones) > 0 {
+		multierror.Append(&err, errors.Check.New("all zones and zones are mutually exclusive").
+			WithContext("msg", "fields AllZones and Zones set together"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o CheckCanApplyExtensionsOptions) WithExtension(extension string) CheckCanApplyExtensionsOptions {
+
+	newO := o
+	newO.Extension = extension
+	return newO
+
+}
+
+func (o CheckCanApplyExtensionsOptions) WithAllZones() CheckCanApplyExtensionsOptions {
+
+	newO := o
+	newO.AllZones = true
+	newO.Zones = ""
+	return newO
+
+}
+
+func (o CheckCanApplyExtensionsOptions) WithZones(zones ...string) CheckCanApplyExtensionsOptions {
+
+	newO := o
+	newO.AllZones = false
+	newO.Zones = strings.Join(zones, ",")
+	return newO
+
+}
+
+///CheckConfig [-ConfigLogIntegrity] [-IncorrectReferences] [-ThinClient] [-WebClient] [-MobileClient] [-Server]
+//[-ExternalConnection] [-ExternalConnectionServer] [-MobileAppClient] [-MobileAppServer]
+//[-ThickClientManagedApplication] [-ThickClientServerManagedApplication]
+//[-ThickClientOrdinaryApplication] [-ThickClientServerOrdinaryApplication]
+//[-MobileClientDigiSign] [-DistributiveModules] [-UnreferenceProcedures] [-HandlersExistence]
+//[-EmptyHandlers] [-ExtendedModulesCheck] [-CheckUseSynchronousCalls] [-CheckUseModality]
+//[-UnsupportedFunctional] [-Extension <имя расширения>] [-AllExtensions]
+//— централизованная проверка конфигурации.
+type CheckConfigOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/CheckConfig" json:"-"`
+
+	//-ConfigLogIntegrity — проверка логической целостности конфигурации.
+	// Стандартная проверка, обычно выполняемая перед обновлением базы данных;
+	ConfigLogIntegrity bool `v8:"-ConfigLogIntegrity, optional" json:"config_log_integrity"`
+
+	//-IncorrectReferences — поиск некорректных ссылок.
+	// Поиск ссылок на удаленные объекты. Выполняется поиск по всей конфигурации,
+	// включая права, формы, макеты, интерфейсы и т.д. Также осуществляется поиск логически неправильных ссылок;
+	IncorrectReferences bool `v8:"-IncorrectReferences, optional" json:"incorrect_references"`
+
+	//-ThinClient — синтаксический контроль модулей для режима эмуляции среды управляемого приложения (тонкий клиент),
+	// выполняемого в файловом режиме;
+	ThinClient bool `v8:"-ThinClient, optional" json:"thin_client"`
+
+	//-WebClient — синтаксический контроль модулей в режиме эмуляции среды веб-клиента;
+	WebClient bool `v8:"-WebClient, optional" json:"web_client"`
+
+	//-MobileClient — синтаксический контроль модулей в режиме эмуляции среды мобильного клиента;
+	MobileClient bool `v8:"-MobileClient, optional" json:"mobile_client"`
+
+	//-Server — синтаксический контроль модулей в режиме эмуляции среды сервера «1С:Предприятия»;
+	Server bool `v8:"-Server, optional" json:"server"`
+
+	//-ExternalConnection — синтаксический контроль модулей в режиме эмуляции среды внешнего соединения,
+	// выполняемого в файловом режиме;
+	ExternalConnection bool `v8:"-ExternalConnection, optional" json:"external_connection"`
+
+	//-ExternalConnectionServer — синтаксический контроль модулей в режиме эмуляции среды внешнего соединения,
+	// выполняемого в клиент-серверном режиме;
+	ExternalConnectionServer bool `v8:"-ExternalConnectionServer, optional" json:"external_connection_server"`
+
+	//-MobileAppClient — синтаксический контроль модулей в режиме эмуляции среды мобильного приложения,
+	// выполняемого в клиентском режиме запуска;
+	MobileAppClient bool `v8:"-MobileAppClient, optional" json:"mobile_app_client"`
+
+	//-MobileAppServer — синтаксический контроль модулей в режиме эмуляции среды мобильного приложения,
+	// выполняемого в серверном режиме запуска;
+	MobileAppServer bool `v8:"-MobileAppServer, optional" json:"mobile_app_server"`
+
+	//-ThickClientManagedApplication — синтаксический контроль модулей в режиме эмуляции среды управляемого приложения
+	// (толстый клиент), выполняемого в файловом режиме;
+	ThickClientManagedApplication bool `v8:"-ThickClientManagedApplication, optional" json:"thick_client_managed_application"`
+
+	//-ThickClientServerManagedApplication — синтаксический контроль модулей в режиме эмуляции среды управляемого приложения
+	// (толстый клиент), выполняемого в клиент-серверном режиме;
+	ThickClientServerManagedApplication bool `v8:"-ThickClientServerManagedApplication, optional" json:"thick_client_server_managed_application"`
+
+	//-ThickClientOrdinaryApplication — синтаксический контроль модулей в режиме эмуляции среды обычного приложения
+	// (толстый клиент), выполняемого в файловом режиме;
+	ThickClientOrdinaryApplication bool `v8:"-ThickClientOrdinaryApplication, optional" json:"thick_client_ordinary_application"`
+
+	//-ThickClientServerOrdinaryApplication — синтаксический контроль модулей в режиме эмуляции среды обычного приложения
+	// (толстый клиент), выполняемого в клиент-серверном режиме;
+	ThickClientServerOrdinaryApplication bool `v8:"-ThickClientServerOrdinaryApplication, optional" json:"thick_client_server_ordinary_application"`
+
+	//-MobileClientDigiSign — проверка подписи мобильного клиента;
+	MobileClientDigiSign bool `v8:"-MobileClientDigiSign, optional" json:"mobile_client_digi_sign"`
+
+	//-DistributiveModules — поставка модулей без исходных текстов.
+	// В случае, если в настройках поставки конфигурации для некоторых модулей указана поставка без исходных текстов,
+	// проверяется возможность генерации образов этих модулей;
+	DistributiveModules bool `v8:"-DistributiveModules, optional" json:"distributive_modules"`
+
+	//-UnreferenceProcedures — поиск неиспользуемых процедур и функций.
+	// Поиск локальных (не экспортных) процедур и функций, на которые отсутствуют ссылки.
+	// В том числе осуществляется поиск неиспользуемых обработчиков событий;
+	UnreferenceProcedures bool `v8:"-UnreferenceProcedures, optional" json:"unreference_procedures"`
+
+	//-HandlersExistence — проверка существования назначенных обработчиков.
+	// Проверка существования обработчиков событий интерфейсов, форм и элементов управления;
+	HandlersExistence bool `v8:"-HandlersExistence, optional" json:"handlers_existence"`
+
+	//-EmptyHandlers — поиск пустых обработчиков.
+	// Поиск назначенных обработчиков событий, в которых не выполняется никаких действий.
+	// Существование таких обработчиков может привести к снижению производительности системы;
+	EmptyHandlers bool `v8:"-EmptyHandlers, optional" json:"empty_handlers"`
+
+	//-ExtendedModulesCheck — проверка обращений к методам и свойствам объектов «через точку»
+	// (для ограниченного набора типов); проверка правильности строковых литералов – параметров некоторых функций,
+	// таких как ПолучитьФорму();
+	ExtendedModulesCheck bool `v8:"-ExtendedModulesCheck, optional" json:"extended_modules_check"`
+
+	//-CheckUseSynchronousCalls — поиск использования синхронных методов в клиентском коде;
+	CheckUseSynchronousCalls bool `v8:"-CheckUseSynchronousCalls, optional" json:"check_use_synchronous_calls"`
+
+	//-CheckUseModality — режим поиска использования в модулях методов, связанных с модальностью.
+	// Опция используется только вместе с опцией -ExtendedModulesCheck;
+	CheckUseModality bool `v8:"-CheckUseModality, optional" json:"check_use_modality"`
+
+	//-UnsupportedFunctional — выполняется поиск функциональности, которая не может быть выполнена
+	// на мобильном приложении;
+	UnsupportedFunctional bool `v8:"-UnsupportedFunctional, optional" json:"unsupported_functional"`
+
+	//-Extension <Имя расширения> — будет выполнена проверка расширения с указанным именем.
+	// Если расширение успешно обработано возвращает код возврата 0,
+	// в противном случае (если расширение с указанным именем не существует или в процессе работы произошли ошибки) — 1;
+	Extension string `v8:"-Extension, optional" json:"extension"`
+
+	//-AllExtensions — проверка всех расширений.
+	AllExtensions bool `v8:"-AllExtensions, optional" json:"all_extensions"`
+}
+
+func (o CheckConfigOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: -Extension и -AllExtensions взаимоисключающие,
+// -CheckUseModality используется только с -ExtendedModulesCheck
+func (o CheckConfigOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Extension) > 0 && o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension and all extensions are mutually exclusive").
+			WithContext("msg", "fields Extension and AllExtensions set together"))
+	}
+
+	if o.CheckUseModality && !o.ExtendedModulesCheck {
+		multierror.Append(&err, errors.Check.New("check use modality requires extended modules check").
+			WithContext("msg", "field CheckUseModality set without ExtendedModulesCheck"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o CheckConfigOptions) WithExtension(extension string) CheckConfigOptions {
+
+	newO := o
+	newO.Extension = extension
+	newO.AllExtensions = false
+	return newO
+
+}
+
+func (o CheckConfigOptions) WithAllExtensions() CheckConfigOptions {
+
+	newO := o
+	newO.Extension = ""
+	newO.AllExtensions = true
+	return newO
+
+}
+
+// CheckConfigForServer проверка конфигурации для серверного контекста выполнения:
+// логическая целостность, некорректные ссылки, существование обработчиков
+// и синтаксический контроль модулей сервера и внешнего соединения.
+func CheckConfigForServer() CheckConfigOptions {
+
+	return CheckConfigOptions{
+		Designer:                 NewDesigner(),
+		ConfigLogIntegrity:       true,
+		IncorrectReferences:      true,
+		Server:                   true,
+		ExternalConnection:       true,
+		ExternalConnectionServer: true,
+		HandlersExistence:        true,
+		ExtendedModulesCheck:     true,
+	}
+
+}
+
+// CheckConfigForThinClient проверка конфигурации управляемого приложения:
+// синтаксический контроль модулей тонкого клиента, веб-клиента и сервера,
+// а также поиск модальных и синхронных вызовов.
+func CheckConfigForThinClient() CheckConfigOptions {
+
+	return CheckConfigOptions{
+		Designer:                 NewDesigner(),
+		ConfigLogIntegrity:       true,
+		IncorrectReferences:      true,
+		ThinClient:               true,
+		WebClient:                true,
+		Server:                   true,
+		HandlersExistence:        true,
+		EmptyHandlers:            true,
+		ExtendedModulesCheck:     true,
+		CheckUseModality:         true,
+		CheckUseSynchronousCalls: true,
+	}
+
+}
+
+// CheckConfigForMobile проверка конфигурации мобильного приложения.
+func CheckConfigForMobile() CheckConfigOptions {
+
+	return CheckConfigOptions{
+		Designer:              NewDesigner(),
+		ConfigLogIntegrity:    true,
+		IncorrectReferences:   true,
+		MobileAppClient:       true,
+		MobileAppServer:       true,
+		HandlersExistence:     true,
+		ExtendedModulesCheck:  true,
+		UnsupportedFunctional: true,
+	}
+
+}
+
+///CheckModules [-ThinClient] [-WebClient] [-Server] [-ExternalConnection] [-ThickClientOrdinaryApplication]
+//[-MobileAppClient] [-MobileAppServer] [-MobileClient] [-ExtendedModulesCheck]
+//[-Extension <имя расширения>] [-AllExtensions]
+//— выполнить синтаксический контроль. Должен быть указан хотя бы один из режимов проверки.
+type CheckModulesOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/CheckModules" json:"-"`
+
+	//-ThinClient — проверка в режиме работы тонкого клиента;
+	ThinClient bool `v8:"-ThinClient, optional" json:"thin_client"`
+
+	//-WebClient — проверка в режиме работы веб-клиента;
+	WebClient bool `v8:"-WebClient, optional" json:"web_client"`
+
+	//-Server — проверка в режиме работы сервера 1С:Предприятия;
+	Server bool `v8:"-Server, optional" json:"server"`
+
+	//-ExternalConnection — проверка в режиме работы внешнего соединения;
+	ExternalConnection bool `v8:"-ExternalConnection, optional" json:"external_connection"`
+
+	//-ThickClientOrdinaryApplication — проверка в режиме работы клиентского приложения;
+	ThickClientOrdinaryApplication bool `v8:"-ThickClientOrdinaryApplication, optional" json:"thick_client_ordinary_application"`
+
+	//-MobileAppClient — проверка в режиме работы мобильного приложения, выполняемого в режиме клиента;
+	MobileAppClient bool `v8:"-MobileAppClient, optional" json:"mobile_app_client"`
+
+	//-MobileAppServer — проверка в режиме работы мобильного приложения, выполняемого в режиме сервера;
+	MobileAppServer bool `v8:"-MobileAppServer, optional" json:"mobile_app_server"`
+
+	//-MobileClient — проверка в режиме работы мобильного клиента;
+	MobileClient bool `v8:"-MobileClient, optional" json:"mobile_client"`
+
+	//-ExtendedModulesCheck — проверка обращений к методам и свойствам объектов «через точку»
+	// (для ограниченного набора типов); проверка правильности строковых литералов – параметров некоторых функций,
+	// таких как ПолучитьФорму();
+	ExtendedModulesCheck bool `v8:"-ExtendedModulesCheck, optional" json:"extended_modules_check"`
+
+	//-Extension <Имя расширения> — будет выполнена проверка расширения с указанным именем.
+	Extension string `v8:"-Extension, optional" json:"extension"`
+
+	//-AllExtensions — проверка всех расширений.
+	AllExtensions bool `v8:"-AllExtensions, optional" json:"all_extensions"`
+}
+
+func (o CheckModulesOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: должен быть указан хотя бы один режим проверки,
+// -Extension и -AllExtensions взаимоисключающие
+func (o CheckModulesOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if !(o.ThinClient || o.WebClient || o.Server || o.ExternalConnection || o.ThickClientOrdinaryApplication ||
+		o.MobileAppClient || o.MobileAppServer || o.MobileClient) {
+		multierror.Append(&err, errors.Check.New("check mode must be set").
+			WithContext("msg", "at least one check mode must be set"))
+	}
+
+	if len(o.Extension) > 0 && o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension and all extensions are mutually exclusive").
+			WithContext("msg", "fields Extension and AllExtensions set together"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o CheckModulesOptions) WithExtension(extension string) CheckModulesOptions {
+
+	newO := o
+	newO.Extension = extension
+	newO.AllExtensions = false
+	return newO
+
+}
+
+func (o CheckModulesOptions) WithAllExtensions() CheckModulesOptions {
+
+	newO := o
+	newO.Extension = ""
+	newO.AllExtensions = true
+	return newO
+
+}
+
+// CheckModulesForServer синтаксический контроль модулей сервера и внешнего соединения.
+func CheckModulesForServer() CheckModulesOptions {
+
+	return CheckModulesOptions{
+		Designer:             NewDesigner(),
+		Server:               true,
+		ExternalConnection:   true,
+		ExtendedModulesCheck: true,
+	}
+
+}
+
+// CheckModulesForThinClient синтаксический контроль модулей тонкого клиента, веб-клиента и сервера.
+func CheckModulesForThinClient() CheckModulesOptions {
+
+	return CheckModulesOptions{
+		Designer:             NewDesigner(),
+		ThinClient:           true,
+		WebClient:            true,
+		Server:               true,
+		ExtendedModulesCheck: true,
+	}
+
+}
@@ -1,11 +1,13 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/suite"
 	"github.com/v8platform/designer/tests"
 	"github.com/v8platform/errors"
 	"github.com/v8platform/runner"
 	"path"
+	"reflect"
 	"testing"
 )
 
@@ -76,3 +78,143 @@ func (t *designerTestSuite) TestUpdateCfg() {
 	//t.R().Equal(codes[0].PromocodeID, "START", "Промокод должен быть START")
 
 }
+
+func TestCheckConfigOptions_Values(t *testing.T) {
+
+	tests := []struct {
+		name string
+		cmd  CheckConfigOptions
+		want []string
+	}{
+		{
+			"server",
+			CheckConfigForServer(),
+			[]string{
+				"/DisableStartupDialogs",
+				"/DisableStartupMessages",
+				"/CheckConfig",
+				"-ConfigLogIntegrity",
+				"-IncorrectReferences",
+				"-Server",
+				"-ExternalConnection",
+				"-ExternalConnectionServer",
+				"-HandlersExistence",
+				"-ExtendedModulesCheck",
+			},
+		},
+		{
+			"extension",
+			CheckConfigOptions{ThinClient: true}.WithExtension("temp_ext"),
+			[]string{
+				"/CheckConfig",
+				"-ThinClient",
+				"-Extension temp_ext",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckModulesOptions_Check(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cmd     CheckModulesOptions
+		wantErr bool
+	}{
+		{"server", CheckModulesForServer(), false},
+		{"thin client", CheckModulesForThinClient().WithAllExtensions(), false},
+		{"no mode", CheckModulesOptions{ExtendedModulesCheck: true}, true},
+		{"extensions", CheckModulesOptions{Server: true, Extension: "temp_ext", AllExtensions: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateDBCfgOptions_Values(t *testing.T) {
+
+	cmd := UpdateDBCfgOptions{BackgroundStart: true, Dynamic: true}
+	want := []string{"/UpdateDBCfg", "-Dynamic +", "-BackgroundStart"}
+
+	if got := cmd.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestOptions_Check(t *testing.T) {
+
+	repository := Repository{Path: "./repo", User: "admin"}
+
+	tests := []struct {
+		name string
+		cmd  runner.Command
+		// количество нарушений в ошибке проверки
+		wantErrs int
+	}{
+		{"designer no truncate", DumpIBOptions{Designer: Designer{NoTruncate: true}, File: "./1.dt"}, 1},
+		{"dump ib", DumpIBOptions{File: "./1.dt"}, 0},
+		{"dump ib no file", DumpIBOptions{}, 1},
+		{"restore ib no file", RestoreIBOptions{}, 1},
+		{"dump cfg no file", DumpCfgOptions{Extension: "temp_ext"}, 1},
+		{"update db cfg", UpdateDBCfgOptions{BackgroundStart: true, Dynamic: true}, 0},
+		{"update db cfg background", UpdateDBCfgOptions{BackgroundStart: true, BackgroundCancel: true}, 1},
+		{"update cfg", UpdateCfgOptions{File: "./1.cfu"}.WithUpdateDBCfg(UpdateDBCfgOptions{Server: true}), 0},
+		{
+			"update cfg all violations",
+			UpdateCfgOptions{IncludeObjectsByUnresolvedRefs: true, ClearUnresolvedRefs: true}.
+				WithUpdateDBCfg(UpdateDBCfgOptions{BackgroundFinish: true, BackgroundSuspend: true}),
+			3,
+		},
+		{"load cfg nested update", LoadCfgOptions{File: "./1.cf"}.WithUpdateDBCfg(UpdateDBCfgOptions{BackgroundStart: true, BackgroundResume: true}), 1},
+		{"load config from files", LoadConfigFromFiles{Dir: "./src"}.WithFiles("Catalogs/Items.xml"), 0},
+		{"load config from files files and list", LoadConfigFromFiles{Dir: "./src", Files: FileList{"a.xml"}, ListFile: "./list.txt"}, 1},
+		{"load config from files extensions", LoadConfigFromFiles{Extension: "temp_ext", AllExtensions: true}, 2},
+		{"load external data file", LoadExternalDataFileFromFilesOptions{}, 2},
+		{"dump config to files", DumpConfigToFilesOptions{Dir: "./src"}.WithUpdate("./ConfigDumpInfo.xml"), 0},
+		{"dump config to files extensions", DumpConfigToFilesOptions{Dir: "./src", Extension: "temp_ext", AllExtensions: true}, 1},
+		{"dump config to files changes without update", DumpConfigToFilesOptions{Dir: "./src", ConfigDumpInfoForChanges: "./ConfigDumpInfo.xml"}, 1},
+		{"get changes no file", GetChangesForConfigDumpOptions{Dir: "./src"}, 1},
+		{"dump external data file", DumpExternalDataFileToFilesOptions{Dir: "./src/root.xml", File: "./epf.epf"}, 0},
+		{"reduce event log size", ReduceEventLogSizeOptions{Date: "2020-01-31"}, 0},
+		{"reduce event log size bad date", ReduceEventLogSizeOptions{Date: "31.01.2020"}, 1},
+		{"agent", AgentModeOptions{SSHHostKeyAuto: true, Port: 1543}, 0},
+		{"agent ssh key", AgentModeOptions{SSHHostKeyAuto: true, SSHHostKey: "./key", Port: 70000}, 2},
+		{"repository update cfg", repository.UpdateCfg(-1), 0},
+		{"repository no path", Repository{}.UpdateCfg(-1), 1},
+		{"repository report versions", repository.Report("./report.mxl", 5, 2), 1},
+		{"repository add user", repository.AddUser("", "", ""), 2},
+		{"repository copy users", Repository{}.CopyUsers("", "", ""), 3},
+		{"create file infobase", CreateFileInfoBaseOptions{}, 1},
+		{"create server infobase", CreateServerInfoBaseOptions{Srvr: "srv", Ref: "ib", CrSQLDB: true}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := tt.cmd.Check()
+
+			got := 0
+
+			if err != nil {
+				got = 1
+				if merr, ok := err.(*multierror.Error); ok {
+					got = len(merr.Errors)
+				}
+			}
+
+			if got != tt.wantErrs {
+				t.Errorf("Check() error = %v, want %d errors", err, tt.wantErrs)
+			}
+		})
+	}
+}
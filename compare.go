@@ -0,0 +1,317 @@
+package designer
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/marshaler"
+	"io/ioutil"
+)
+
+type CompareConfigurationType string
+type CompareMappingRuleType string
+type CompareReportType string
+type CompareReportFormatType string
+
+func (t CompareConfigurationType) MarshalV8() (string, error) {
+	return string(t), nil
+}
+
+func (t CompareMappingRuleType) MarshalV8() (string, error) {
+	return string(t), nil
+}
+
+func (t CompareReportType) MarshalV8() (string, error) {
+	return string(t), nil
+}
+
+func (t CompareReportFormatType) MarshalV8() (string, error) {
+	return string(t), nil
+}
+
+const (
+	COMPARE_CONFIGURATION_MAIN           CompareConfigurationType = "MainConfiguration"
+	COMPARE_CONFIGURATION_DB             CompareConfigurationType = "DBConfiguration"
+	COMPARE_CONFIGURATION_VENDOR         CompareConfigurationType = "VendorConfiguration"
+	COMPARE_CONFIGURATION_EXTENSION      CompareConfigurationType = "ExtensionConfiguration"
+	COMPARE_CONFIGURATION_EXTENSION_DB   CompareConfigurationType = "ExtensionDBConfiguration"
+	COMPARE_CONFIGURATION_REPOSITORY     CompareConfigurationType = "ConfigurationRepository"
+	COMPARE_CONFIGURATION_FILE           CompareConfigurationType = "File"
+	COMPARE_MAPPING_RULE_BY_OBJECT_NAMES CompareMappingRuleType   = "ByObjectNames"
+	COMPARE_MAPPING_RULE_BY_OBJECT_IDS   CompareMappingRuleType   = "ByObjectIDs"
+	COMPARE_REPORT_BRIEF                 CompareReportType        = "Brief"
+	COMPARE_REPORT_FULL                  CompareReportType        = "Full"
+	COMPARE_REPORT_FORMAT_TXT            CompareReportFormatType  = "txt"
+	COMPARE_REPORT_FORMAT_MXL            CompareReportFormatType  = "mxl"
+)
+
+///CompareCfg -FirstConfigurationType <тип первой конфигурации> [-FirstName <имя первой конфигурации>]
+//[-FirstFile <файл первой конфигурации>] -SecondConfigurationType <тип второй конфигурации>
+//[-SecondName <имя второй конфигурации>] [-SecondFile <файл второй конфигурации>]
+//[-MappingRule <правило сопоставления>] [-Objects <путь к файлу>] [-IncludeChangedObjects]
+//[-IncludeDeletedObjects] [-IncludeAddedObjects]
+//-ReportType <тип отчета> -ReportFormat <формат отчета> -ReportFile <файл отчета>
+//— построение отчета о сравнении конфигураций.
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /CompareCfg -FirstConfigurationType MainConfiguration
+// -SecondConfigurationType File -SecondFile "D:\Vendor\1Cv8.cf" -ReportType Full -ReportFormat txt -ReportFile "D:\report.txt"
+type CompareCfgOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/CompareCfg" json:"-"`
+
+	//-FirstConfigurationType <тип первой конфигурации> — тип первой конфигурации. Возможные значения:
+	//	MainConfiguration — основная конфигурация,
+	//	DBConfiguration — конфигурация базы данных,
+	//	VendorConfiguration — конфигурация поставщика,
+	//	ExtensionConfiguration — расширение конфигурации,
+	//	ExtensionDBConfiguration — расширение конфигурации базы данных,
+	//	ConfigurationRepository — конфигурация из хранилища,
+	//	File — файл конфигурации.
+	FirstConfigurationType CompareConfigurationType `v8:"-FirstConfigurationType" json:"first_configuration_type"`
+
+	//-FirstName <имя первой конфигурации> — для VendorConfiguration указывается имя конфигурации поставщика,
+	// для ExtensionConfiguration и ExtensionDBConfiguration — имя расширения.
+	FirstName string `v8:"-FirstName, optional" json:"first_name"`
+
+	//-FirstVersion <версия первой конфигурации> — для ConfigurationRepository номер версии хранилища
+	// (если не указан, используется последняя версия).
+	FirstVersion int64 `v8:"-FirstVersion, optional" json:"first_version"`
+
+	//-FirstFile <файл первой конфигурации> — путь к файлу конфигурации для типа File.
+	FirstFile string `v8:"-FirstFile, optional" json:"first_file"`
+
+	//-SecondConfigurationType <тип второй конфигурации> — тип второй конфигурации.
+	// Возможные значения аналогичны -FirstConfigurationType.
+	SecondConfigurationType CompareConfigurationType `v8:"-SecondConfigurationType" json:"second_configuration_type"`
+
+	//-SecondName <имя второй конфигурации> — аналогично -FirstName.
+	SecondName string `v8:"-SecondName, optional" json:"second_name"`
+
+	//-SecondVersion <версия второй конфигурации> — аналогично -FirstVersion.
+	SecondVersion int64 `v8:"-SecondVersion, optional" json:"second_version"`
+
+	//-SecondFile <файл второй конфигурации> — путь к файлу конфигурации для типа File.
+	SecondFile string `v8:"-SecondFile, optional" json:"second_file"`
+
+	//-MappingRule <правило сопоставления> — правило сопоставления объектов конфигураций. Возможные значения:
+	//	ByObjectNames — по именам объектов,
+	//	ByObjectIDs — по идентификаторам объектов.
+	MappingRule CompareMappingRuleType `v8:"-MappingRule, optional" json:"mapping_rule"`
+
+	//-Objects <путь к файлу> — путь к файлу формата XML со списком объектов,
+	// которые будут участвовать в сравнении.
+	Objects string `v8:"-Objects, optional" json:"objects"`
+
+	//-IncludeChangedObjects — включать в отчет измененные подчиненные объекты.
+	IncludeChangedObjects bool `v8:"-IncludeChangedObjects, optional" json:"include_changed_objects"`
+
+	//-IncludeDeletedObjects — включать в отчет удаленные подчиненные объекты.
+	IncludeDeletedObjects bool `v8:"-IncludeDeletedObjects, optional" json:"include_deleted_objects"`
+
+	//-IncludeAddedObjects — включать в отчет добавленные подчиненные объекты.
+	IncludeAddedObjects bool `v8:"-IncludeAddedObjects, optional" json:"include_added_objects"`
+
+	//-ReportType <тип отчета> — тип отчета. Возможные значения:
+	//	Brief — краткий отчет,
+	//	Full — полный отчет.
+	ReportType CompareReportType `v8:"-ReportType" json:"report_type"`
+
+	//-ReportFormat <формат отчета> — формат файла отчета. Возможные значения:
+	//	txt — текстовый документ,
+	//	mxl — табличный документ.
+	ReportFormat CompareReportFormatType `v8:"-ReportFormat" json:"report_format"`
+
+	//-ReportFile <файл отчета> — путь к файлу, в который будет выведен отчет.
+	ReportFile string `v8:"-ReportFile" json:"report_file"`
+}
+
+func (o CompareCfgOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: типы сравниваемых конфигураций с их именами или файлами,
+// тип, формат и файл отчета обязательны
+func (o CompareCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	checkSide := func(side string, t CompareConfigurationType, name, file string) {
+
+		switch t {
+		case "":
+			multierror.Append(&err, errors.Check.New(side+" configuration type must be set").
+				WithContext("msg", "field "+side+"ConfigurationType not set"))
+		case COMPARE_CONFIGURATION_FILE:
+			if len(file) == 0 {
+				multierror.Append(&err, errors.Check.New(side+" configuration file must be set").
+					WithContext("msg", "field "+side+"File not set"))
+			}
+		case COMPARE_CONFIGURATION_EXTENSION, COMPARE_CONFIGURATION_EXTENSION_DB, COMPARE_CONFIGURATION_VENDOR:
+			if len(name) == 0 {
+				multierror.Append(&err, errors.Check.New(side+" configuration name must be set").
+					WithContext("msg", "field "+side+"Name not set"))
+			}
+		}
+
+	}
+
+	checkSide("First", o.FirstConfigurationType, o.FirstName, o.FirstFile)
+	checkSide("Second", o.SecondConfigurationType, o.SecondName, o.SecondFile)
+
+	if len(o.ReportType) == 0 {
+		multierror.Append(&err, errors.Check.New("report type must be set").
+			WithContext("msg", "field ReportType not set"))
+	}
+
+	if len(o.ReportFormat) == 0 {
+		multierror.Append(&err, errors.Check.New("report format must be set").
+			WithContext("msg", "field ReportFormat not set"))
+	}
+
+	if len(o.ReportFile) == 0 {
+		multierror.Append(&err, errors.Check.New("report file must be set").
+			WithContext("msg", "field ReportFile not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o CompareCfgOptions) WithFirst(configurationType CompareConfigurationType, name ...string) CompareCfgOptions {
+
+	newO := o
+	newO.FirstConfigurationType = configurationType
+	newO.FirstName = ""
+	newO.FirstVersion = 0
+	newO.FirstFile = ""
+
+	if len(name) > 0 {
+		newO.FirstName = name[0]
+	}
+
+	return newO
+
+}
+
+func (o CompareCfgOptions) WithSecond(configurationType CompareConfigurationType, name ...string) CompareCfgOptions {
+
+	newO := o
+	newO.SecondConfigurationType = configurationType
+	newO.SecondName = ""
+	newO.SecondVersion = 0
+	newO.SecondFile = ""
+
+	if len(name) > 0 {
+		newO.SecondName = name[0]
+	}
+
+	return newO
+
+}
+
+func (o CompareCfgOptions) WithFirstFile(file string) CompareCfgOptions {
+
+	newO := o.WithFirst(COMPARE_CONFIGURATION_FILE)
+	newO.FirstFile = file
+	return newO
+
+}
+
+func (o CompareCfgOptions) WithSecondFile(file string) CompareCfgOptions {
+
+	newO := o.WithSecond(COMPARE_CONFIGURATION_FILE)
+	newO.SecondFile = file
+	return newO
+
+}
+
+// WithFirstRepository первая конфигурация — версия хранилища. Версия 0 или меньше — последняя версия
+func (o CompareCfgOptions) WithFirstRepository(version int64) CompareCfgOptions {
+
+	newO := o.WithFirst(COMPARE_CONFIGURATION_REPOSITORY)
+	newO.FirstVersion = repositoryVersion(version)
+	return newO
+
+}
+
+// WithSecondRepository вторая конфигурация — версия хранилища. Версия 0 или меньше — последняя версия
+func (o CompareCfgOptions) WithSecondRepository(version int64) CompareCfgOptions {
+
+	newO := o.WithSecond(COMPARE_CONFIGURATION_REPOSITORY)
+	newO.SecondVersion = repositoryVersion(version)
+	return newO
+
+}
+
+func (o CompareCfgOptions) WithMappingRule(rule CompareMappingRuleType) CompareCfgOptions {
+
+	newO := o
+	newO.MappingRule = rule
+	return newO
+
+}
+
+func (o CompareCfgOptions) WithObjects(objectsFile string) CompareCfgOptions {
+
+	newO := o
+	newO.Objects = objectsFile
+	return newO
+
+}
+
+func (o CompareCfgOptions) WithReport(reportType CompareReportType, format CompareReportFormatType, file string) CompareCfgOptions {
+
+	newO := o
+	newO.ReportType = reportType
+	newO.ReportFormat = format
+	newO.ReportFile = file
+	return newO
+
+}
+
+// ReadReport возвращает текст отчета о сравнении конфигураций.
+// Используется после выполнения команды для отчета в формате txt.
+func (o CompareCfgOptions) ReadReport() (string, error) {
+
+	if o.ReportFormat == COMPARE_REPORT_FORMAT_MXL {
+		return "", errors.Invalid.New("report in mxl format can not be read as text")
+	}
+
+	data, err := ioutil.ReadFile(o.ReportFile)
+
+	if err != nil {
+		return "", errors.NotExist.Wrapf(err, "read compare report")
+	}
+
+	return decodeV8Text(data), nil
+
+}
+
+// NewCompareCfg создает команду сравнения конфигураций с полным отчетом в текстовом формате
+func NewCompareCfg(first, second CompareConfigurationType, reportFile string) CompareCfgOptions {
+
+	return CompareCfgOptions{
+		Designer:                NewDesigner(),
+		FirstConfigurationType:  first,
+		SecondConfigurationType: second,
+		ReportType:              COMPARE_REPORT_FULL,
+		ReportFormat:            COMPARE_REPORT_FORMAT_TXT,
+		ReportFile:              reportFile,
+	}
+
+}
+
+func repositoryVersion(version int64) int64 {
+
+	if version <= 0 {
+		return 0
+	}
+
+	return version
+}
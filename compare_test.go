@@ -0,0 +1,118 @@
+package designer
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestCompareCfgOptions_Values(t *testing.T) {
+
+	tests := []struct {
+		name string
+		cmd  CompareCfgOptions
+		want []string
+	}{
+		{
+			"main with file",
+			CompareCfgOptions{}.
+				WithFirst(COMPARE_CONFIGURATION_MAIN).
+				WithSecondFile("./1Cv8.cf").
+				WithReport(COMPARE_REPORT_BRIEF, COMPARE_REPORT_FORMAT_TXT, "./report.txt"),
+			[]string{
+				"/CompareCfg",
+				"-FirstConfigurationType MainConfiguration",
+				"-SecondConfigurationType File",
+				"-SecondFile ./1Cv8.cf",
+				"-ReportType Brief",
+				"-ReportFormat txt",
+				"-ReportFile ./report.txt",
+			},
+		},
+		{
+			"repository with vendor",
+			CompareCfgOptions{IncludeChangedObjects: true}.
+				WithFirstRepository(12).
+				WithSecond(COMPARE_CONFIGURATION_VENDOR, "Бухгалтерия предприятия").
+				WithMappingRule(COMPARE_MAPPING_RULE_BY_OBJECT_IDS).
+				WithObjects("./objects.xml").
+				WithReport(COMPARE_REPORT_FULL, COMPARE_REPORT_FORMAT_MXL, "./report.mxl"),
+			[]string{
+				"/CompareCfg",
+				"-FirstConfigurationType ConfigurationRepository",
+				"-FirstVersion 12",
+				"-SecondConfigurationType VendorConfiguration",
+				"-SecondName Бухгалтерия предприятия",
+				"-MappingRule ByObjectIDs",
+				"-Objects ./objects.xml",
+				"-IncludeChangedObjects",
+				"-ReportType Full",
+				"-ReportFormat mxl",
+				"-ReportFile ./report.mxl",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareCfgOptions_Check(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cmd     CompareCfgOptions
+		wantErr bool
+	}{
+		{
+			"main with db",
+			NewCompareCfg(COMPARE_CONFIGURATION_MAIN, COMPARE_CONFIGURATION_DB, "./report.txt"),
+			false,
+		},
+		{
+			"file without path",
+			NewCompareCfg(COMPARE_CONFIGURATION_MAIN, COMPARE_CONFIGURATION_FILE, "./report.txt"),
+			true,
+		},
+		{
+			"extension without name",
+			NewCompareCfg(COMPARE_CONFIGURATION_EXTENSION, COMPARE_CONFIGURATION_MAIN, "./report.txt"),
+			true,
+		},
+		{
+			"no report file",
+			NewCompareCfg(COMPARE_CONFIGURATION_MAIN, COMPARE_CONFIGURATION_DB, ""),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompareCfgOptions_ReadReport(t *testing.T) {
+
+	report, _ := ioutil.TempFile("", "v8_compare_*.txt")
+	_, _ = report.Write([]byte("\xef\xbb\xbfОтчет о сравнении"))
+	report.Close()
+	defer os.Remove(report.Name())
+
+	got, err := NewCompareCfg(COMPARE_CONFIGURATION_MAIN, COMPARE_CONFIGURATION_DB, report.Name()).ReadReport()
+
+	if err != nil {
+		t.Fatalf("ReadReport() error = %v", err)
+	}
+
+	if got != "Отчет о сравнении" {
+		t.Errorf("ReadReport() = %q", got)
+	}
+}
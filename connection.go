@@ -0,0 +1,392 @@
+package designer
+
+import (
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"strings"
+)
+
+var (
+	_ runner.Infobase = (*FileInfobase)(nil)
+	_ runner.Infobase = (*ServerInfobase)(nil)
+	_ runner.Infobase = (*WSInfobase)(nil)
+)
+
+// InfobaseAuth параметры аутентификации и запуска, общие для всех типов информационных баз
+type InfobaseAuth struct {
+	// Usr — имя пользователя информационной базы
+	User string `json:"user"`
+
+	// Pwd — пароль пользователя информационной базы
+	Password string `json:"password"`
+
+	// Locale — язык (страна), который будет использован при открытии информационной базы
+	Locale string `json:"locale"`
+
+	// UC — код доступа для подключения к информационной базе с установленной блокировкой сеансов
+	UC string `json:"uc"`
+}
+
+func (a InfobaseAuth) params() []string {
+
+	var p []string
+
+	if len(a.User) > 0 {
+		p = append(p, connectionParam("Usr", a.User))
+		p = append(p, connectionParam("Pwd", a.Password))
+	}
+
+	if len(a.Locale) > 0 {
+		p = append(p, connectionParam("Locale", a.Locale))
+	}
+
+	if len(a.UC) > 0 {
+		p = append(p, connectionParam("UC", a.UC))
+	}
+
+	return p
+
+}
+
+// FileInfobase файловая информационная база
+type FileInfobase struct {
+	InfobaseAuth `json:"auth"`
+
+	// File — каталог информационной базы
+	File string `json:"file"`
+}
+
+// ServerInfobase клиент-серверная информационная база
+type ServerInfobase struct {
+	InfobaseAuth `json:"auth"`
+
+	// Srvr — имя сервера «1С:Предприятия» в формате [<протокол>://]<адрес>[:<порт>]
+	Srvr string `json:"server"`
+
+	// Ref — имя информационной базы на сервере «1С:Предприятия»
+	Ref string `json:"ref"`
+}
+
+// WSInfobase информационная база, опубликованная на веб-сервере
+type WSInfobase struct {
+	InfobaseAuth `json:"auth"`
+
+	// ws — адрес публикации информационной базы
+	URL string `json:"url"`
+
+	// wsn — имя пользователя веб-сервера
+	WSUser string `json:"ws_user"`
+
+	// wsp — пароль пользователя веб-сервера
+	WSPassword string `json:"ws_password"`
+}
+
+func NewFileInfobase(file string) FileInfobase {
+	return FileInfobase{File: file}
+}
+
+func NewServerInfobase(srvr, ref string) ServerInfobase {
+	return ServerInfobase{Srvr: srvr, Ref: ref}
+}
+
+func NewWSInfobase(url string) WSInfobase {
+	return WSInfobase{URL: url}
+}
+
+// Path возвращает каталог файловой информационной базы
+func (ib FileInfobase) Path() string {
+	return ib.File
+}
+
+// String возвращает строку соединения с информационной базой, например File="D:\base";Usr="Admin";Pwd="";
+func (ib FileInfobase) String() string {
+	return joinConnectionParams(append([]string{connectionParam("File", ib.File)}, ib.params()...))
+}
+
+// ConnectionString возвращает параметр запуска /IBConnectionString
+func (ib FileInfobase) ConnectionString() string {
+	return "/IBConnectionString " + ib.String()
+}
+
+func (ib FileInfobase) WithCredentials(user, password string) FileInfobase {
+
+	newIb := ib
+	newIb.User = user
+	newIb.Password = password
+	return newIb
+
+}
+
+func (ib FileInfobase) WithLocale(locale string) FileInfobase {
+
+	newIb := ib
+	newIb.Locale = locale
+	return newIb
+
+}
+
+func (ib FileInfobase) WithUC(uc string) FileInfobase {
+
+	newIb := ib
+	newIb.UC = uc
+	return newIb
+
+}
+
+func (ib ServerInfobase) String() string {
+	return joinConnectionParams(append([]string{
+		connectionParam("Srvr", ib.Srvr),
+		connectionParam("Ref", ib.Ref),
+	}, ib.params()...))
+}
+
+func (ib ServerInfobase) ConnectionString() string {
+	return "/IBConnectionString " + ib.String()
+}
+
+func (ib ServerInfobase) WithCredentials(user, password string) ServerInfobase {
+
+	newIb := ib
+	newIb.User = user
+	newIb.Password = password
+	return newIb
+
+}
+
+func (ib ServerInfobase) WithLocale(locale string) ServerInfobase {
+
+	newIb := ib
+	newIb.Locale = locale
+	return newIb
+
+}
+
+func (ib ServerInfobase) WithUC(uc string) ServerInfobase {
+
+	newIb := ib
+	newIb.UC = uc
+	return newIb
+
+}
+
+func (ib WSInfobase) String() string {
+
+	p := []string{connectionParam("ws", ib.URL)}
+
+	if len(ib.WSUser) > 0 {
+		p = append(p, connectionParam("wsn", ib.WSUser))
+		p = append(p, connectionParam("wsp", ib.WSPassword))
+	}
+
+	return joinConnectionParams(append(p, ib.params()...))
+}
+
+func (ib WSInfobase) ConnectionString() string {
+	return "/IBConnectionString " + ib.String()
+}
+
+func (ib WSInfobase) WithCredentials(user, password string) WSInfobase {
+
+	newIb := ib
+	newIb.User = user
+	newIb.Password = password
+	return newIb
+
+}
+
+func (ib WSInfobase) WithWSCredentials(user, password string) WSInfobase {
+
+	newIb := ib
+	newIb.WSUser = user
+	newIb.WSPassword = password
+	return newIb
+
+}
+
+func (ib WSInfobase) WithLocale(locale string) WSInfobase {
+
+	newIb := ib
+	newIb.Locale = locale
+	return newIb
+
+}
+
+func (ib WSInfobase) WithUC(uc string) WSInfobase {
+
+	newIb := ib
+	newIb.UC = uc
+	return newIb
+
+}
+
+// ParseConnectionString разбирает строку соединения и возвращает FileInfobase, ServerInfobase или WSInfobase.
+// Поддерживаются форматы:
+//
+//	File="D:\base";Usr="Admin";
+//	/IBConnectionString Srvr="server";Ref="base";
+//	/F"D:\base", /S"server\base"
+//	Connect=ws="http://host/base"; (строка из файла списка информационных баз v8i)
+func ParseConnectionString(connectionString string) (runner.Infobase, error) {
+
+	s := strings.TrimSpace(connectionString)
+
+	switch {
+	case hasPrefixFold(s, "Connect="):
+		s = s[len("Connect="):]
+	case hasPrefixFold(s, "/IBConnectionString"):
+		s = strings.TrimSpace(s[len("/IBConnectionString"):])
+	case hasPrefixFold(s, "/F"):
+		return FileInfobase{File: unquoteConnectionValue(strings.TrimSpace(s[2:]))}, nil
+	case hasPrefixFold(s, "/S"):
+		path := unquoteConnectionValue(strings.TrimSpace(s[2:]))
+		i := strings.LastIndexAny(path, `\/`)
+		if i <= 0 || i == len(path)-1 {
+			return nil, errors.Invalid.Newf("invalid server infobase path <%s>", path)
+		}
+		return ServerInfobase{Srvr: path[:i], Ref: path[i+1:]}, nil
+	}
+
+	params, err := splitConnectionString(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var auth InfobaseAuth
+	var file, srvr, ref, ws, wsn, wsp string
+	var hasFile, hasSrvr, hasWS bool
+
+	for _, p := range params {
+
+		switch strings.ToLower(p[0]) {
+		case "file":
+			file, hasFile = p[1], true
+		case "srvr":
+			srvr, hasSrvr = p[1], true
+		case "ref":
+			ref = p[1]
+		case "ws":
+			ws, hasWS = p[1], true
+		case "wsn":
+			wsn = p[1]
+		case "wsp":
+			wsp = p[1]
+		case "usr":
+			auth.User = p[1]
+		case "pwd":
+			auth.Password = p[1]
+		case "locale":
+			auth.Locale = p[1]
+		case "uc":
+			auth.UC = p[1]
+		}
+
+	}
+
+	switch {
+	case hasFile:
+		return FileInfobase{InfobaseAuth: auth, File: file}, nil
+	case hasSrvr:
+		if len(ref) == 0 {
+			return nil, errors.Invalid.Newf("infobase ref not set in connection string <%s>", connectionString)
+		}
+		return ServerInfobase{InfobaseAuth: auth, Srvr: srvr, Ref: ref}, nil
+	case hasWS:
+		return WSInfobase{InfobaseAuth: auth, URL: ws, WSUser: wsn, WSPassword: wsp}, nil
+	}
+
+	return nil, errors.Invalid.Newf("unknown infobase type in connection string <%s>", connectionString)
+
+}
+
+func connectionParam(key, value string) string {
+	return key + `="` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+func joinConnectionParams(params []string) string {
+	return strings.Join(params, ";") + ";"
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func unquoteConnectionValue(value string) string {
+
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.ReplaceAll(value[1:len(value)-1], `""`, `"`)
+	}
+
+	return value
+
+}
+
+// splitConnectionString разбивает строку соединения на пары ключ-значение.
+// Значения могут быть заключены в кавычки, кавычки внутри значения удваиваются.
+func splitConnectionString(s string) ([][2]string, error) {
+
+	var params [][2]string
+
+	for i := 0; i < len(s); {
+
+		for i < len(s) && (s[i] == ';' || s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+
+		if i >= len(s) {
+			break
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+
+		if eq < 0 {
+			return nil, errors.Invalid.Newf("invalid connection string parameter <%s>", s[i:])
+		}
+
+		key := strings.TrimSpace(s[i : i+eq])
+		i += eq + 1
+
+		var value strings.Builder
+
+		if i < len(s) && s[i] == '"' {
+
+			i++
+			closed := false
+
+			for i < len(s) {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						value.WriteByte('"')
+						i += 2
+						continue
+					}
+					i++
+					closed = true
+					break
+				}
+				value.WriteByte(s[i])
+				i++
+			}
+
+			if !closed {
+				return nil, errors.Invalid.Newf("unclosed quote in connection string parameter <%s>", key)
+			}
+
+		} else {
+
+			end := strings.IndexByte(s[i:], ';')
+			if end < 0 {
+				end = len(s) - i
+			}
+			value.WriteString(strings.TrimSpace(s[i : i+end]))
+			i += end
+
+		}
+
+		params = append(params, [2]string{key, value.String()})
+
+	}
+
+	return params, nil
+
+}
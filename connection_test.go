@@ -0,0 +1,100 @@
+package designer
+
+import (
+	"github.com/v8platform/runner"
+	"reflect"
+	"testing"
+)
+
+func TestInfobase_ConnectionString(t *testing.T) {
+
+	tests := []struct {
+		name string
+		ib   runner.Infobase
+		want string
+	}{
+		{
+			"file",
+			NewFileInfobase(`D:\V8\ИБ83`),
+			`/IBConnectionString File="D:\V8\ИБ83";`,
+		},
+		{
+			"file with auth",
+			NewFileInfobase("./ib").WithCredentials("Admin", `pa"ss`).WithLocale("ru_RU").WithUC("123"),
+			`/IBConnectionString File="./ib";Usr="Admin";Pwd="pa""ss";Locale="ru_RU";UC="123";`,
+		},
+		{
+			"server",
+			NewServerInfobase("app:1541", "base").WithCredentials("Admin", ""),
+			`/IBConnectionString Srvr="app:1541";Ref="base";Usr="Admin";Pwd="";`,
+		},
+		{
+			"ws",
+			NewWSInfobase("http://host/base").WithWSCredentials("web", "secret"),
+			`/IBConnectionString ws="http://host/base";wsn="web";wsp="secret";`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ib.ConnectionString(); got != tt.want {
+				t.Errorf("ConnectionString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConnectionString(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		str     string
+		want    runner.Infobase
+		wantErr bool
+	}{
+		{
+			"round trip",
+			NewFileInfobase("./ib").WithCredentials("Admin", `pa"ss;word`).WithUC("123").ConnectionString(),
+			NewFileInfobase("./ib").WithCredentials("Admin", `pa"ss;word`).WithUC("123"),
+			false,
+		},
+		{
+			"v8i connect",
+			`Connect=Srvr="app";Ref="base";`,
+			NewServerInfobase("app", "base"),
+			false,
+		},
+		{
+			"unquoted",
+			`ws=http://host/base;Usr=Admin`,
+			NewWSInfobase("http://host/base").WithCredentials("Admin", ""),
+			false,
+		},
+		{
+			"/F",
+			`/F"D:\base"`,
+			NewFileInfobase(`D:\base`),
+			false,
+		},
+		{
+			"/S",
+			`/Sapp:1541\base`,
+			NewServerInfobase("app:1541", "base"),
+			false,
+		},
+		{"unknown", `Foo="bar";`, nil, true},
+		{"server without ref", `Srvr="app";`, nil, true},
+		{"unclosed quote", `File="D:\base`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConnectionString(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConnectionString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseConnectionString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,549 @@
+// Пакет чтения и записи файлов контейнера 1С:Предприятия 8 (.cf, .cfe, .epf, .erf)
+// без запуска конфигуратора.
+//
+// Файл контейнера состоит из заголовка, блока оглавления и пар блоков
+// (заголовок элемента, данные элемента) для каждого элемента.
+// Данные элементов файла верхнего уровня сжаты алгоритмом deflate,
+// данные вложенных контейнеров хранятся без сжатия.
+package container
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"github.com/v8platform/errors"
+	"io/ioutil"
+	"regexp"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	// DEFAULT_PAGE_SIZE размер страницы блока по умолчанию
+	DEFAULT_PAGE_SIZE int32 = 512
+
+	// DEFAULT_STORAGE_VERSION версия формата хранения по умолчанию
+	DEFAULT_STORAGE_VERSION int32 = 1
+
+	endMarker       int32 = 0x7fffffff
+	fileHeaderSize        = 16
+	blockHeaderSize       = 31
+	tocEntrySize          = 12
+	entryHeaderSize       = 20
+
+	// количество 1/10000 секунды между 0001-01-01 и 1970-01-01
+	v8EpochOffset = 62135596800 * 10000
+)
+
+// Container содержимое файла контейнера
+type Container struct {
+	// PageSize размер страницы блоков контейнера
+	PageSize int32
+
+	// StorageVersion версия формата хранения из заголовка контейнера
+	StorageVersion int32
+
+	Entries []*Entry
+}
+
+// Entry элемент контейнера
+type Entry struct {
+	Name     string
+	Created  time.Time
+	Modified time.Time
+
+	// Data распакованные данные элемента. Не заполняется, если элемент является вложенным контейнером
+	Data []byte
+
+	// Container вложенный контейнер
+	Container *Container
+}
+
+// New создает пустой контейнер
+func New() *Container {
+
+	return &Container{
+		PageSize: DEFAULT_PAGE_SIZE,
+	}
+
+}
+
+// ReadFile читает файл контейнера (.cf, .cfe, .epf, .erf)
+func ReadFile(file string) (*Container, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data, true)
+}
+
+// Parse разбирает данные контейнера.
+// Признак compressed указывает, что данные элементов сжаты (контейнер верхнего уровня).
+func Parse(data []byte, compressed bool) (*Container, error) {
+
+	if !IsContainer(data) {
+		return nil, errors.Invalid.New("bad container signature")
+	}
+
+	c := &Container{
+		PageSize:       int32(binary.LittleEndian.Uint32(data[4:8])),
+		StorageVersion: int32(binary.LittleEndian.Uint32(data[8:12])),
+	}
+
+	toc, err := readBlock(data, fileHeaderSize)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "read container table of contents")
+	}
+
+	for i := 0; i+tocEntrySize <= len(toc); i += tocEntrySize {
+
+		headerAddr := int32(binary.LittleEndian.Uint32(toc[i:]))
+		dataAddr := int32(binary.LittleEndian.Uint32(toc[i+4:]))
+
+		if headerAddr == 0 && dataAddr == 0 {
+			continue
+		}
+
+		entry, err := readEntry(data, int64(headerAddr), int64(dataAddr), compressed)
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.Entries = append(c.Entries, entry)
+	}
+
+	return c, nil
+}
+
+// IsContainer проверяет наличие заголовка контейнера в данных
+func IsContainer(data []byte) bool {
+
+	if len(data) < fileHeaderSize+blockHeaderSize {
+		return false
+	}
+
+	return int32(binary.LittleEndian.Uint32(data)) == endMarker &&
+		data[fileHeaderSize] == '\r' && data[fileHeaderSize+1] == '\n'
+}
+
+// Entry возвращает элемент контейнера по имени или nil, если элемент не найден
+func (c *Container) Entry(name string) *Entry {
+
+	for _, e := range c.Entries {
+		if e.Name == name {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Add добавляет элемент в контейнер или заменяет существующий элемент с тем же именем
+func (c *Container) Add(entry *Entry) {
+
+	for i, e := range c.Entries {
+		if e.Name == entry.Name {
+			c.Entries[i] = entry
+			return
+		}
+	}
+
+	c.Entries = append(c.Entries, entry)
+}
+
+var reFormatVersion = regexp.MustCompile(`\{\s*(\d+)\s*,\s*(\d+)`)
+
+// FormatVersion возвращает версию формата контейнера из элемента version, например 216.0
+func (c *Container) FormatVersion() (string, error) {
+
+	entry := c.Entry("version")
+
+	if entry == nil || entry.Data == nil {
+		return "", errors.NotExist.New("container entry version not found")
+	}
+
+	m := reFormatVersion.FindSubmatch(entry.Data)
+
+	if m == nil {
+		return "", errors.Invalid.New("bad container version entry")
+	}
+
+	return fmt.Sprintf("%s.%s", m[1], m[2]), nil
+}
+
+// Size возвращает размер распакованных данных элемента.
+// Для вложенного контейнера возвращается сумма размеров его элементов.
+func (e *Entry) Size() int64 {
+
+	if e.Container == nil {
+		return int64(len(e.Data))
+	}
+
+	var size int64
+
+	for _, child := range e.Container.Entries {
+		size += child.Size()
+	}
+
+	return size
+}
+
+// IsContainer признак того, что элемент является вложенным контейнером
+func (e *Entry) IsContainer() bool {
+	return e.Container != nil
+}
+
+// Bytes возвращает данные контейнера.
+// Признак compressed указывает, что данные элементов будут сжаты (контейнер верхнего уровня).
+func (c *Container) Bytes(compressed bool) ([]byte, error) {
+
+	pageSize := c.PageSize
+
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+
+	type block struct {
+		header []byte
+		data   []byte
+	}
+
+	blocks := make([]block, 0, len(c.Entries))
+
+	for _, e := range c.Entries {
+
+		data, err := e.bytes()
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "write container entry %s", e.Name)
+		}
+
+		if compressed {
+			data, err = deflate(data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "compress container entry %s", e.Name)
+			}
+		}
+
+		blocks = append(blocks, block{header: e.header(), data: data})
+	}
+
+	tocSize := int32(len(blocks) * tocEntrySize)
+	tocPage := maxInt32(tocSize, pageSize)
+
+	toc := make([]byte, 0, tocSize)
+	offset := int32(fileHeaderSize + blockHeaderSize + tocPage)
+
+	for _, b := range blocks {
+
+		headerAddr := offset
+		offset += blockHeaderSize + int32(len(b.header))
+
+		dataAddr := offset
+		offset += blockHeaderSize + maxInt32(int32(len(b.data)), pageSize)
+
+		toc = appendInt32(toc, headerAddr)
+		toc = appendInt32(toc, dataAddr)
+		toc = appendInt32(toc, endMarker)
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(offset))
+
+	buf.Write(appendInt32(appendInt32(appendInt32(appendInt32(nil, endMarker), pageSize), c.StorageVersion), 0))
+	writeBlock(&buf, toc, tocPage)
+
+	for _, b := range blocks {
+		writeBlock(&buf, b.header, int32(len(b.header)))
+		writeBlock(&buf, b.data, maxInt32(int32(len(b.data)), pageSize))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteFile записывает контейнер в файл (.cf, .cfe, .epf, .erf)
+func (c *Container) WriteFile(file string) error {
+
+	data, err := c.Bytes(true)
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+func (e *Entry) bytes() ([]byte, error) {
+
+	if e.Container != nil {
+		return e.Container.Bytes(false)
+	}
+
+	return e.Data, nil
+}
+
+func (e *Entry) header() []byte {
+
+	name := utf16.Encode([]rune(e.Name))
+
+	h := make([]byte, 0, entryHeaderSize+len(name)*2+4)
+	h = appendInt64(h, timeToV8(e.Created))
+	h = appendInt64(h, timeToV8(e.Modified))
+	h = appendInt32(h, 0)
+
+	for _, r := range name {
+		h = append(h, byte(r), byte(r>>8))
+	}
+
+	return appendInt32(h, 0)
+}
+
+func readEntry(data []byte, headerAddr, dataAddr int64, compressed bool) (*Entry, error) {
+
+	header, err := readBlock(data, headerAddr)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "read container entry header at %d", headerAddr)
+	}
+
+	if len(header) < entryHeaderSize {
+		return nil, errors.Invalid.Newf("bad container entry header at %d", headerAddr)
+	}
+
+	entry := &Entry{
+		Name:     decodeName(header[entryHeaderSize:]),
+		Created:  v8ToTime(int64(binary.LittleEndian.Uint64(header[0:8]))),
+		Modified: v8ToTime(int64(binary.LittleEndian.Uint64(header[8:16]))),
+	}
+
+	if dataAddr == int64(endMarker) {
+		entry.Data = []byte{}
+		return entry, nil
+	}
+
+	raw, err := readBlock(data, dataAddr)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "read container entry %s", entry.Name)
+	}
+
+	if compressed {
+		raw, err = inflate(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decompress container entry %s", entry.Name)
+		}
+	}
+
+	if IsContainer(raw) {
+		if nested, err := Parse(raw, false); err == nil {
+			entry.Container = nested
+			return entry, nil
+		}
+	}
+
+	entry.Data = raw
+
+	return entry, nil
+}
+
+func readBlock(data []byte, addr int64) ([]byte, error) {
+
+	size, _, _, err := readBlockHeader(data, addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if size < 0 || int64(size) > int64(len(data)) {
+		return nil, errors.Invalid.Newf("bad container block size %d at %d", size, addr)
+	}
+
+	result := make([]byte, 0, size)
+	visited := map[int64]bool{}
+
+	for len(result) < int(size) {
+
+		if visited[addr] {
+			return nil, errors.Invalid.Newf("cyclic container block chain at %d", addr)
+		}
+		visited[addr] = true
+
+		_, page, next, err := readBlockHeader(data, addr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if page <= 0 {
+			return nil, errors.Invalid.Newf("bad container block page size %d at %d", page, addr)
+		}
+
+		start := addr + blockHeaderSize
+		take := int64(page)
+
+		if rest := int64(size) - int64(len(result)); take > rest {
+			take = rest
+		}
+
+		if start+take > int64(len(data)) {
+			return nil, errors.Invalid.Newf("container block at %d out of range", addr)
+		}
+
+		result = append(result, data[start:start+take]...)
+
+		if next == endMarker {
+			break
+		}
+
+		addr = int64(next)
+	}
+
+	if len(result) < int(size) {
+		return nil, errors.Invalid.Newf("container block truncated: %d of %d bytes", len(result), size)
+	}
+
+	return result, nil
+}
+
+func readBlockHeader(data []byte, addr int64) (size, page, next int32, err error) {
+
+	if addr < 0 || addr+blockHeaderSize > int64(len(data)) {
+		return 0, 0, 0, errors.Invalid.Newf("container block header at %d out of range", addr)
+	}
+
+	h := data[addr : addr+blockHeaderSize]
+
+	if h[0] != '\r' || h[1] != '\n' || h[10] != ' ' || h[19] != ' ' || h[28] != ' ' || h[29] != '\r' || h[30] != '\n' {
+		return 0, 0, 0, errors.Invalid.Newf("bad container block header at %d", addr)
+	}
+
+	var values [3]int32
+
+	for i := range values {
+		v, err := parseHex(h[2+i*9 : 10+i*9])
+		if err != nil {
+			return 0, 0, 0, errors.Invalid.Newf("bad container block header at %d", addr)
+		}
+		values[i] = v
+	}
+
+	return values[0], values[1], values[2], nil
+}
+
+func writeBlock(buf *bytes.Buffer, data []byte, page int32) {
+
+	fmt.Fprintf(buf, "\r\n%08x %08x %08x \r\n", len(data), page, endMarker)
+	buf.Write(data)
+
+	if pad := int(page) - len(data); pad > 0 {
+		buf.Write(make([]byte, pad))
+	}
+
+}
+
+func parseHex(b []byte) (int32, error) {
+
+	var v uint32
+
+	for _, c := range b {
+		switch {
+		case c >= '0' && c <= '9':
+			v = v<<4 | uint32(c-'0')
+		case c >= 'a' && c <= 'f':
+			v = v<<4 | uint32(c-'a'+10)
+		case c >= 'A' && c <= 'F':
+			v = v<<4 | uint32(c-'A'+10)
+		default:
+			return 0, fmt.Errorf("bad hex digit %q", c)
+		}
+	}
+
+	return int32(v), nil
+}
+
+func decodeName(b []byte) string {
+
+	u := make([]uint16, 0, len(b)/2)
+
+	for i := 0; i+1 < len(b); i += 2 {
+		r := binary.LittleEndian.Uint16(b[i:])
+		if r == 0 {
+			break
+		}
+		u = append(u, r)
+	}
+
+	return string(utf16.Decode(u))
+}
+
+func inflate(data []byte) ([]byte, error) {
+
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+func deflate(data []byte) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func v8ToTime(v int64) time.Time {
+
+	if v == 0 {
+		return time.Time{}
+	}
+
+	v -= v8EpochOffset
+
+	return time.Unix(v/10000, (v%10000)*int64(100*time.Microsecond)).UTC()
+}
+
+func timeToV8(t time.Time) int64 {
+
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()*10000 + int64(t.Nanosecond())/int64(100*time.Microsecond) + v8EpochOffset
+}
+
+func appendInt32(b []byte, v int32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendInt64(b []byte, v int64) []byte {
+	return appendInt32(appendInt32(b, int32(v)), int32(v>>32))
+}
+
+func maxInt32(a, b int32) int32 {
+
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,195 @@
+package container
+
+import (
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func fixture(elem ...string) string {
+	return filepath.Join(append([]string{"..", "tests", "fixtures"}, elem...)...)
+}
+
+func TestReadFile(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		file          string
+		entries       []string
+		formatVersion string
+	}{
+		{
+			"cf 0.9",
+			fixture("0.9", "1Cv8.cf"),
+			[]string{
+				"000a6b23-9e90-4a09-9ef9-4e74e3d36865",
+				"129648e0-a492-4829-9b13-09d82bbaf353",
+				"337a8ad3-4fcc-40b2-b64b-4f2d7b616d91.4",
+				"root",
+				"version",
+				"versions",
+			},
+			"216.0",
+		},
+		{
+			"epf",
+			fixture("epf", "Test_Close.epf"),
+			[]string{
+				"132a2301-ef0b-4f92-b883-cc2b357944ec",
+				"963d61f9-1a4c-463e-8622-cab58460a525",
+				"963d61f9-1a4c-463e-8622-cab58460a525.0",
+				"copyinfo",
+				"root",
+				"version",
+				"versions",
+			},
+			"216.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			c, err := ReadFile(tt.file)
+			require.NoError(t, err)
+
+			var names []string
+			for _, e := range c.Entries {
+				names = append(names, e.Name)
+			}
+			require.Equal(t, tt.entries, names)
+
+			version, err := c.FormatVersion()
+			require.NoError(t, err)
+			require.Equal(t, tt.formatVersion, version)
+		})
+	}
+}
+
+func TestReadFile_Nested(t *testing.T) {
+
+	c, err := ReadFile(fixture("1.0", "1Cv8.cf"))
+	require.NoError(t, err)
+
+	entry := c.Entry("5844ebe1-ddaa-4c79-a667-6776331f154f.0")
+	require.NotNil(t, entry)
+	require.True(t, entry.IsContainer())
+	require.NotNil(t, entry.Container.Entry("info"))
+	require.NotNil(t, entry.Container.Entry("text"))
+
+	require.Contains(t, c.List(), EntryInfo{
+		Path: "5844ebe1-ddaa-4c79-a667-6776331f154f.0/info",
+		Size: 15,
+	})
+
+	root := c.Entry("root")
+	require.NotNil(t, root)
+	require.False(t, root.IsContainer())
+	require.True(t, root.Modified.After(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestContainer_Bytes(t *testing.T) {
+
+	files := []string{
+		fixture("0.9", "1Cv8.cf"),
+		fixture("1.0", "1Cv8.cf"),
+		fixture("epf", "Test_Close.epf"),
+		fixture("epf", "Test_Params.epf"),
+	}
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+
+			c, err := ReadFile(file)
+			require.NoError(t, err)
+
+			data, err := c.Bytes(true)
+			require.NoError(t, err)
+
+			got, err := Parse(data, true)
+			require.NoError(t, err)
+			require.Equal(t, c, got)
+		})
+	}
+}
+
+func TestExtractAndBuild(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "v8_container_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	require.NoError(t, ExtractFile(fixture("1.0", "1Cv8.cf"), src))
+
+	text, err := ioutil.ReadFile(filepath.Join(src, "5844ebe1-ddaa-4c79-a667-6776331f154f.0", "text"))
+	require.NoError(t, err)
+	require.Len(t, text, 159)
+
+	file := filepath.Join(dir, "1Cv8.cf")
+	require.NoError(t, BuildFile(src, file))
+
+	original, err := ReadFile(fixture("1.0", "1Cv8.cf"))
+	require.NoError(t, err)
+
+	built, err := ReadFile(file)
+	require.NoError(t, err)
+
+	require.Equal(t, len(original.List()), len(built.List()))
+
+	for _, info := range original.List() {
+		require.Contains(t, built.List(), info)
+	}
+
+	require.Equal(t, original.StorageVersion, built.StorageVersion)
+	require.Equal(t, original.PageSize, built.PageSize)
+}
+
+func TestParse_Invalid(t *testing.T) {
+
+	_, err := Parse([]byte("not a container"), true)
+	require.Error(t, err)
+
+	data, err := ioutil.ReadFile(fixture("0.9", "1Cv8.cf"))
+	require.NoError(t, err)
+
+	_, err = Parse(data[:200], true)
+	require.Error(t, err)
+}
+
+func TestParse_CorruptedBlockHeader(t *testing.T) {
+
+	data, err := ioutil.ReadFile(fixture("0.9", "1Cv8.cf"))
+	require.NoError(t, err)
+
+	// заголовок блока оглавления: \r\n<размер> <страница> <следующий> \r\n
+	const (
+		sizeOffset = fileHeaderSize + 2
+		pageOffset = fileHeaderSize + 11
+	)
+
+	tests := []struct {
+		name   string
+		offset int
+		value  string
+	}{
+		{"negative size", sizeOffset, "ffffffff"},
+		{"size out of range", sizeOffset, "7ffffff0"},
+		{"negative page", pageOffset, "ffffffff"},
+		{"zero page", pageOffset, "00000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			corrupted := append([]byte{}, data...)
+			copy(corrupted[tt.offset:], tt.value)
+
+			require.NotPanics(t, func() {
+				_, err = Parse(corrupted, true)
+			})
+			require.Error(t, err)
+		})
+	}
+}
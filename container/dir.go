@@ -0,0 +1,245 @@
+package container
+
+import (
+	"fmt"
+	"github.com/v8platform/errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// HEADER_FILE файл каталога распаковки с параметрами заголовка контейнера
+// (размер страницы и версия формата хранения), используется FromDir
+const HEADER_FILE = ".container"
+
+// EntryInfo описание элемента контейнера
+type EntryInfo struct {
+	// Path путь к элементу внутри контейнера. Элементы вложенных контейнеров разделяются символом /
+	Path string
+
+	Size int64
+
+	// IsContainer признак того, что элемент является вложенным контейнером
+	IsContainer bool
+}
+
+// List возвращает список всех элементов контейнера, включая элементы вложенных контейнеров
+func (c *Container) List() []EntryInfo {
+
+	var list []EntryInfo
+
+	_ = c.Walk(func(p string, e *Entry) error {
+		list = append(list, EntryInfo{
+			Path:        p,
+			Size:        e.Size(),
+			IsContainer: e.IsContainer(),
+		})
+		return nil
+	})
+
+	return list
+}
+
+// Walk обходит все элементы контейнера, включая элементы вложенных контейнеров.
+// Обход прерывается при возврате ошибки из функции fn.
+func (c *Container) Walk(fn func(path string, e *Entry) error) error {
+	return c.walk("", fn)
+}
+
+func (c *Container) walk(parent string, fn func(path string, e *Entry) error) error {
+
+	for _, e := range c.Entries {
+
+		p := path.Join(parent, e.Name)
+
+		if err := fn(p, e); err != nil {
+			return err
+		}
+
+		if e.Container != nil {
+			if err := e.Container.walk(p, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Extract распаковывает элементы контейнера в каталог.
+// Вложенные контейнеры распаковываются в подкаталоги с именем элемента.
+func (c *Container) Extract(dir string) error {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	if err := c.writeHeader(dir); err != nil {
+		return err
+	}
+
+	for _, e := range c.Entries {
+
+		if err := checkEntryName(e.Name); err != nil {
+			return err
+		}
+
+		name := filepath.Join(dir, e.Name)
+
+		if e.Container != nil {
+			if err := e.Container.Extract(name); err != nil {
+				return err
+			}
+		} else if err := ioutil.WriteFile(name, e.Data, 0644); err != nil {
+			return err
+		}
+
+		if !e.Modified.IsZero() {
+			_ = os.Chtimes(name, e.Modified, e.Modified)
+		}
+	}
+
+	return nil
+}
+
+// ExtractFile распаковывает файл контейнера в каталог
+func ExtractFile(file, dir string) error {
+
+	c, err := ReadFile(file)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Extract(dir)
+}
+
+// FromDir собирает контейнер из каталога, созданного Extract.
+// Файлы каталога становятся элементами контейнера, подкаталоги — вложенными контейнерами.
+// Элементы упорядочиваются по имени.
+// Размер страницы и версия формата хранения читаются из файла HEADER_FILE,
+// при его отсутствии используются DEFAULT_PAGE_SIZE и DEFAULT_STORAGE_VERSION.
+func FromDir(dir string) (*Container, error) {
+
+	infos, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name() < infos[j].Name()
+	})
+
+	c, err := readHeader(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+
+		if info.Name() == HEADER_FILE {
+			continue
+		}
+
+		name := filepath.Join(dir, info.Name())
+
+		entry := &Entry{
+			Name:     info.Name(),
+			Created:  info.ModTime(),
+			Modified: info.ModTime(),
+		}
+
+		if info.IsDir() {
+			entry.Container, err = FromDir(name)
+		} else {
+			entry.Data, err = ioutil.ReadFile(name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		c.Entries = append(c.Entries, entry)
+	}
+
+	return c, nil
+}
+
+// BuildFile собирает файл контейнера из каталога, созданного Extract
+func BuildFile(dir, file string) error {
+
+	c, err := FromDir(dir)
+
+	if err != nil {
+		return err
+	}
+
+	return c.WriteFile(file)
+}
+
+func (c *Container) writeHeader(dir string) error {
+
+	header := fmt.Sprintf("PageSize=%d\nStorageVersion=%d\n", c.PageSize, c.StorageVersion)
+
+	return ioutil.WriteFile(filepath.Join(dir, HEADER_FILE), []byte(header), 0644)
+}
+
+func readHeader(dir string) (*Container, error) {
+
+	c := New()
+	c.StorageVersion = DEFAULT_STORAGE_VERSION
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, HEADER_FILE))
+
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var value int32
+
+		switch {
+		case strings.HasPrefix(line, "PageSize="):
+			_, err = fmt.Sscanf(line, "PageSize=%d", &value)
+			c.PageSize = value
+		case strings.HasPrefix(line, "StorageVersion="):
+			_, err = fmt.Sscanf(line, "StorageVersion=%d", &value)
+			c.StorageVersion = value
+		default:
+			err = fmt.Errorf("unknown parameter")
+		}
+
+		if err != nil || c.PageSize <= 0 {
+			return nil, errors.Invalid.Newf("bad container header file %s: %q", HEADER_FILE, line)
+		}
+	}
+
+	return c, nil
+}
+
+func checkEntryName(name string) error {
+
+	if name == HEADER_FILE {
+		return errors.Invalid.Newf("container entry name %q is reserved", name)
+	}
+
+	if len(name) == 0 || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return errors.Invalid.Newf("bad container entry name %q", name)
+	}
+
+	return nil
+}
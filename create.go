@@ -1,6 +1,8 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 )
 
@@ -149,9 +151,52 @@ func (d CreateFileInfoBaseOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: каталог информационной базы File обязателен
+func (d CreateFileInfoBaseOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.CreateInfoBaseOptions.Check())
+
+	if len(d.File) == 0 {
+		multierror.Append(&err, errors.Check.New("infobase file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (d CreateServerInfoBaseOptions) Values() []string {
 
 	v, _ := marshaler.Marshal(d)
 	return v
 
 }
+
+// Check проверяет параметры команды: сервер Srvr и имя информационной базы Ref обязательны,
+// для создания базы данных (CrSQLDB) обязательны DBMS, DBSrvr и DB
+func (d CreateServerInfoBaseOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.CreateInfoBaseOptions.Check())
+
+	if len(d.Srvr) == 0 {
+		multierror.Append(&err, errors.Check.New("cluster server must be set").
+			WithContext("msg", "field Srvr not set"))
+	}
+
+	if len(d.Ref) == 0 {
+		multierror.Append(&err, errors.Check.New("infobase name must be set").
+			WithContext("msg", "field Ref not set"))
+	}
+
+	if d.CrSQLDB && (len(d.DBMS) == 0 || len(d.DBSrvr) == 0 || len(d.DB) == 0) {
+		multierror.Append(&err, errors.Check.New("database server, type and name must be set").
+			WithContext("msg", "field CrSQLDB set without DBMS, DBSrvr or DB"))
+	}
+
+	return err.ErrorOrNil()
+
+}
@@ -0,0 +1,241 @@
+package designer
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/marshaler"
+	"os"
+)
+
+// DistributionFiles список файлов дистрибутивов предыдущих версий.
+// Для каждого файла указывается свой ключ -f, все файлы передаются одним значением: -f <файл 1> -f <файл 2>.
+type DistributionFiles []string
+
+func (t DistributionFiles) items() []string {
+	return t
+}
+
+// DistributionVersions список версий дистрибутивов из настроек поставки.
+// Для каждой версии указывается свой ключ -v, все версии передаются одним значением: -v <версия 1> -v <версия 2>.
+type DistributionVersions []string
+
+func (t DistributionVersions) items() []string {
+	return t
+}
+
+///CreateDistributionFiles [-cffile <имя cf-файла>] [-cfufile <имя cfu-файла>
+//[-f <имя cf-файла>|-v <версия дистрибутива>]+] [-digisign <имя файла с параметрами лицензирования>]
+//— создание файлов поставки и обновления. Доступны параметры:
+//
+//-cffile <имя cf-файла> — указание создать дистрибутив конфигурации;
+//
+//-cfufile <имя cfu-файла> — указание создать дистрибутив обновления;
+//
+//-f <имя cf-файла> — дистрибутив, включаемый в обновление;
+//
+//-v <версия дистрибутива> — версия дистрибутива, включаемого в обновление.
+//Параметры -f и -v могут повторяться, если в обновление включается несколько дистрибутивов;
+//
+//-digisign <имя файла с параметрами лицензирования> — указать параметры лицензирования
+//«1С:Предприятия» для создаваемых файлов поставки.
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /CreateDistributionFiles -cffile "D:\Release\1Cv8.cf"
+// -cfufile "D:\Release\1Cv8.cfu" -f "D:\Releases\1.0.1\1Cv8.cf" -f "D:\Releases\1.0.2\1Cv8.cf"
+type CreateDistributionFilesOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/CreateDistributionFiles" json:"-"`
+
+	//-cffile <имя cf-файла> — указание создать дистрибутив конфигурации;
+	CfFile string `v8:"-cffile, optional" json:"cf_file"`
+
+	//-cfufile <имя cfu-файла> — указание создать дистрибутив обновления;
+	CfuFile string `v8:"-cfufile, optional" json:"cfu_file"`
+
+	//-f <имя cf-файла> — дистрибутивы предыдущих версий, включаемые в обновление;
+	PreviousFiles DistributionFiles `v8:"-f, optional" json:"previous_files"`
+
+	//-v <версия дистрибутива> — версии дистрибутивов, включаемых в обновление;
+	PreviousVersions DistributionVersions `v8:"-v, optional" json:"previous_versions"`
+
+	//-digisign <имя файла с параметрами лицензирования> — указать параметры лицензирования
+	//«1С:Предприятия» для создаваемых файлов поставки.
+	DigiSign string `v8:"-digisign, optional" json:"digisign"`
+}
+
+func (o CreateDistributionFilesOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return appendRepeatedValues(v, o)
+
+}
+
+// Check проверяет параметры команды: должен быть указан -cffile или -cfufile,
+// предыдущие поставки (-f, -v) используются только с -cfufile, указанные файлы должны существовать
+func (o CreateDistributionFilesOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.CfFile) == 0 && len(o.CfuFile) == 0 {
+		multierror.Append(&err, errors.Check.New("cf or cfu file must be set").
+			WithContext("msg", "field CfFile or CfuFile not set"))
+	}
+
+	if len(o.CfuFile) == 0 && (len(o.PreviousFiles) > 0 || len(o.PreviousVersions) > 0) {
+		multierror.Append(&err, errors.Check.New("previous releases require cfu file").
+			WithContext("msg", "field PreviousFiles or PreviousVersions set without CfuFile"))
+	}
+
+	for _, file := range o.PreviousFiles {
+		if !fileExists(file) {
+			multierror.Append(&err, errors.Check.Newf("previous release file <%s> not found", file).
+				WithContext("msg", "field PreviousFiles contains missing file"))
+		}
+	}
+
+	if len(o.DigiSign) > 0 && !fileExists(o.DigiSign) {
+		multierror.Append(&err, errors.Check.Newf("digisign file <%s> not found", o.DigiSign).
+			WithContext("msg", "field DigiSign contains missing file"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o CreateDistributionFilesOptions) WithPreviousReleases(files ...string) CreateDistributionFilesOptions {
+
+	newO := o
+	newO.PreviousFiles = append(DistributionFiles{}, o.PreviousFiles...)
+	newO.PreviousFiles = append(newO.PreviousFiles, files...)
+	return newO
+
+}
+
+func (o CreateDistributionFilesOptions) WithPreviousVersions(versions ...string) CreateDistributionFilesOptions {
+
+	newO := o
+	newO.PreviousVersions = append(DistributionVersions{}, o.PreviousVersions...)
+	newO.PreviousVersions = append(newO.PreviousVersions, versions...)
+	return newO
+
+}
+
+func (o CreateDistributionFilesOptions) WithDigiSign(file string) CreateDistributionFilesOptions {
+
+	newO := o
+	newO.DigiSign = file
+	return newO
+
+}
+
+// NewCreateDistributionFiles создает команду создания файлов поставки.
+// Пустое имя cf или cfu файла означает, что соответствующий файл создаваться не будет.
+func NewCreateDistributionFiles(cfFile, cfuFile string, previousReleases ...string) CreateDistributionFilesOptions {
+
+	command := CreateDistributionFilesOptions{
+		Designer: NewDesigner(),
+		CfFile:   cfFile,
+		CfuFile:  cfuFile,
+	}
+
+	if len(previousReleases) > 0 {
+		command.PreviousFiles = previousReleases
+	}
+
+	return command
+
+}
+
+///CreateDistributive <каталог создания комплекта поставки> -File <имя файла описания комплекта поставки>
+//[-Option <вариант поставки>] [-MakeSetup] [-MakeFiles] [-digisign <имя файла с параметрами лицензирования>]
+//— создание комплектов поставки и файлов комплекта поставки по готовому описанию комплекта поставки.
+type CreateDistributiveOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	//<каталог создания комплекта поставки> — каталог, в котором будет создан комплект поставки;
+	Dir string `v8:"/CreateDistributive" json:"dir"`
+
+	//-File <имя файла описания комплекта поставки> — файл описания комплекта поставки (.edf);
+	File string `v8:"-File" json:"file"`
+
+	//-Option <вариант поставки> — вариант поставки из описания комплекта поставки.
+	//Если не указан, используется вариант поставки по умолчанию;
+	Option string `v8:"-Option, optional" json:"option"`
+
+	//-MakeSetup — создать комплект поставки (используется по умолчанию);
+	MakeSetup bool `v8:"-MakeSetup, optional" json:"make_setup"`
+
+	//-MakeFiles — создать файлы комплекта поставки;
+	MakeFiles bool `v8:"-MakeFiles, optional" json:"make_files"`
+
+	//-digisign <имя файла с параметрами лицензирования> — указать параметры лицензирования
+	//«1С:Предприятия» для создаваемого комплекта поставки.
+	DigiSign string `v8:"-digisign, optional" json:"digisign"`
+}
+
+func (o CreateDistributiveOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: каталог и файл описания комплекта поставки обязательны,
+// указанные файлы должны существовать
+func (o CreateDistributiveOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Dir) == 0 {
+		multierror.Append(&err, errors.Check.New("distributive dir must be set").
+			WithContext("msg", "field Dir not set"))
+	}
+
+	if len(o.File) == 0 {
+		multierror.Append(&err, errors.Check.New("distributive description file must be set").
+			WithContext("msg", "field File not set"))
+	} else if !fileExists(o.File) {
+		multierror.Append(&err, errors.Check.Newf("distributive description file <%s> not found", o.File).
+			WithContext("msg", "field File contains missing file"))
+	}
+
+	if len(o.DigiSign) > 0 && !fileExists(o.DigiSign) {
+		multierror.Append(&err, errors.Check.Newf("digisign file <%s> not found", o.DigiSign).
+			WithContext("msg", "field DigiSign contains missing file"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o CreateDistributiveOptions) WithOption(option string) CreateDistributiveOptions {
+
+	newO := o
+	newO.Option = option
+	return newO
+
+}
+
+func (o CreateDistributiveOptions) WithDigiSign(file string) CreateDistributiveOptions {
+
+	newO := o
+	newO.DigiSign = file
+	return newO
+
+}
+
+func fileExists(name string) bool {
+
+	info, err := os.Stat(name)
+
+	if err != nil {
+		return false
+	}
+
+	return !info.IsDir()
+}
@@ -0,0 +1,110 @@
+package designer
+
+import (
+	"github.com/v8platform/designer/tests"
+	"github.com/v8platform/runner"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCreateDistributionFilesOptions_Values(t *testing.T) {
+
+	tests := []struct {
+		name string
+		cmd  CreateDistributionFilesOptions
+		want []string
+	}{
+		{
+			"cf",
+			CreateDistributionFilesOptions{CfFile: "./1Cv8.cf"},
+			[]string{
+				"/CreateDistributionFiles",
+				"-cffile ./1Cv8.cf",
+			},
+		},
+		{
+			"cfu with previous releases",
+			CreateDistributionFilesOptions{CfuFile: "./1Cv8.cfu"}.
+				WithPreviousReleases("./1.0.1/1Cv8.cf", "./1.0.2/1Cv8.cf").
+				WithPreviousVersions("1.0.0.1").
+				WithDigiSign("./digisign.lic"),
+			[]string{
+				"/CreateDistributionFiles",
+				"-cfufile ./1Cv8.cfu",
+				"-digisign ./digisign.lic",
+				"-f ./1.0.1/1Cv8.cf -f ./1.0.2/1Cv8.cf",
+				"-v 1.0.0.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateDistributionFilesOptions_Check(t *testing.T) {
+
+	release := filepath.Join("tests", "fixtures", "0.9", "1Cv8.cf")
+
+	tests := []struct {
+		name    string
+		cmd     CreateDistributionFilesOptions
+		wantErr bool
+	}{
+		{"cf", NewCreateDistributionFiles("./1Cv8.cf", ""), false},
+		{"cfu", NewCreateDistributionFiles("", "./1Cv8.cfu", release), false},
+		{"nothing", NewCreateDistributionFiles("", ""), true},
+		{"missing release", NewCreateDistributionFiles("", "./1Cv8.cfu", "./missing/1Cv8.cf"), true},
+		{"release without cfu", NewCreateDistributionFiles("./1Cv8.cf", "", release), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateDistributiveOptions_Values(t *testing.T) {
+
+	cmd := CreateDistributiveOptions{
+		Dir:       "./setup",
+		File:      "./setup.edf",
+		MakeSetup: true,
+	}.WithOption("Основная поставка")
+
+	want := []string{
+		"/CreateDistributive ./setup",
+		"-File ./setup.edf",
+		"-Option Основная поставка",
+		"-MakeSetup",
+	}
+
+	if got := cmd.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+
+	if err := cmd.Check(); err == nil {
+		t.Errorf("Check() must fail for missing description file")
+	}
+}
+
+func TestCreateDistributionFilesOptions_RunnerArgs(t *testing.T) {
+
+	what := NewCreateDistributionFiles("", "./1Cv8.cfu", "./a.cf", "./b.cf").
+		WithPreviousVersions("1.0", "2.0")
+
+	args := runner.NewPlatformRunner(tests.NewFileIB("./ib"), what).Args()
+
+	for _, want := range []string{"-f ./a.cf -f ./b.cf", "-v 1.0 -v 2.0"} {
+		if !containsFold(args, want) {
+			t.Errorf("Args() = %v, want %v", args, want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 )
 
@@ -16,6 +18,22 @@ func (d DumpCfgOptions) Values() []string {
 	return v
 }
 
+// Check проверяет параметры команды: файл выгрузки обязателен
+func (d DumpCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	if len(d.File) == 0 {
+		multierror.Append(&err, errors.Check.New("dump file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (d DumpCfgOptions) WithExtension(extension string) DumpCfgOptions {
 
 	return DumpCfgOptions{
@@ -60,6 +78,13 @@ type DumpConfigToFilesOptions struct {
 	//Имя файла версий должно быть указано.
 	//Примечание. Данная опция используется только совместно с параметрами -update и -getChanges.
 	ConfigDumpInfoForChanges string `v8:"-configDumpInfoForChanges, optional" json:"config_dump_info_for_changes"`
+
+	//listFile <имя файла> — выгрузить только объекты метаданных и/или внешние свойства, указанные в файле.
+	//Указываемый файл должен удовлетворять следующим требованиям:
+	//- Файл должен быть в кодировке UTF-8.
+	//- Полные имена объектов должны быть указаны через перенос строки.
+	//- Файл не должен содержать пустые строки между именами объектов.
+	ListFile string `v8:"-listFile, optional" json:"list_file"`
 }
 
 func (o DumpConfigToFilesOptions) Values() []string {
@@ -68,6 +93,33 @@ func (o DumpConfigToFilesOptions) Values() []string {
 	return v
 }
 
+// Check проверяет параметры команды: каталог выгрузки обязателен,
+// -Extension и -AllExtensions взаимоисключающие, -configDumpInfoForChanges используется только с -update
+func (o DumpConfigToFilesOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Dir) == 0 {
+		multierror.Append(&err, errors.Check.New("dump dir must be set").
+			WithContext("msg", "field Dir not set"))
+	}
+
+	if len(o.Extension) > 0 && o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension and all extensions are mutually exclusive").
+			WithContext("msg", "fields Extension and AllExtensions set together"))
+	}
+
+	if len(o.ConfigDumpInfoForChanges) > 0 && !o.Update {
+		multierror.Append(&err, errors.Check.New("config dump info for changes requires update").
+			WithContext("msg", "field ConfigDumpInfoForChanges set without Update"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o DumpConfigToFilesOptions) WithExtension(extension string) DumpConfigToFilesOptions {
 
 	newO := o
@@ -87,6 +139,14 @@ func (o DumpConfigToFilesOptions) WithUpdate(configDumpInfo string) DumpConfigTo
 
 }
 
+func (o DumpConfigToFilesOptions) WithListFile(file string) DumpConfigToFilesOptions {
+
+	newO := o
+	newO.ListFile = file
+	return newO
+
+}
+
 func (o DumpConfigToFilesOptions) WithAllExtension() DumpConfigToFilesOptions {
 
 	newO := o
@@ -137,6 +197,27 @@ func (o GetChangesForConfigDumpOptions) Values() []string {
 	return v
 }
 
+// Check проверяет параметры команды: каталог выгрузки и файл изменений -getChanges обязательны
+func (o GetChangesForConfigDumpOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Dir) == 0 {
+		multierror.Append(&err, errors.Check.New("dump dir must be set").
+			WithContext("msg", "field Dir not set"))
+	}
+
+	if len(o.GetChanges) == 0 {
+		multierror.Append(&err, errors.Check.New("changes file must be set").
+			WithContext("msg", "field GetChanges not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o GetChangesForConfigDumpOptions) WithExtension(extension string) GetChangesForConfigDumpOptions {
 
 	newO := o
@@ -173,3 +254,24 @@ func (o DumpExternalDataFileToFilesOptions) Values() []string {
 	v, _ := marshaler.Marshal(o)
 	return v
 }
+
+// Check проверяет параметры команды: корневой файл выгрузки и файл внешней обработки или отчета обязательны
+func (o DumpExternalDataFileToFilesOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Dir) == 0 {
+		multierror.Append(&err, errors.Check.New("dump root file must be set").
+			WithContext("msg", "field Dir not set"))
+	}
+
+	if len(o.File) == 0 {
+		multierror.Append(&err, errors.Check.New("external data file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
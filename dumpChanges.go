@@ -0,0 +1,186 @@
+package designer
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/v8platform/errors"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+type ConfigDumpChangeType string
+
+const (
+	CONFIG_DUMP_CHANGE_MODIFIED ConfigDumpChangeType = "Modified"
+	CONFIG_DUMP_CHANGE_NEW      ConfigDumpChangeType = "New"
+	CONFIG_DUMP_CHANGE_DELETED  ConfigDumpChangeType = "Deleted"
+	CONFIG_DUMP_FULL_DUMP                            = "FullDump"
+)
+
+// ConfigDumpChange изменение объекта конфигурации из файла -getChanges
+type ConfigDumpChange struct {
+	Type ConfigDumpChangeType `json:"type"`
+
+	// Name полное имя объекта, например Catalog.Товары.Form.ФормаЭлемента
+	Name string `json:"name"`
+}
+
+// ConfigDumpChanges изменения конфигурации относительно выгрузки (-getChanges)
+type ConfigDumpChanges struct {
+	// FullDump изменения не могут быть вычислены, требуется полная выгрузка
+	FullDump bool `json:"full_dump"`
+
+	Changes []ConfigDumpChange `json:"changes"`
+}
+
+// ParseConfigDumpChanges разбирает файл изменений, полученный параметром -getChanges.
+// Каждая строка файла имеет вид <Тип изменения>:<Полное имя объекта>,
+// строка FullDump означает необходимость полной выгрузки.
+func ParseConfigDumpChanges(r io.Reader) (ConfigDumpChanges, error) {
+
+	var changes ConfigDumpChanges
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		lineNum++
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if strings.EqualFold(line, CONFIG_DUMP_FULL_DUMP) {
+			changes.FullDump = true
+			continue
+		}
+
+		i := strings.IndexByte(line, ':')
+
+		if i <= 0 {
+			return ConfigDumpChanges{}, errors.Invalid.Newf("line %d: invalid change <%s>", lineNum, line)
+		}
+
+		change := ConfigDumpChange{
+			Type: ConfigDumpChangeType(strings.TrimSpace(line[:i])),
+			Name: strings.TrimSpace(line[i+1:]),
+		}
+
+		switch change.Type {
+		case CONFIG_DUMP_CHANGE_MODIFIED, CONFIG_DUMP_CHANGE_NEW, CONFIG_DUMP_CHANGE_DELETED:
+		default:
+			return ConfigDumpChanges{}, errors.Invalid.Newf("line %d: unknown change type <%s>", lineNum, change.Type)
+		}
+
+		changes.Changes = append(changes.Changes, change)
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ConfigDumpChanges{}, err
+	}
+
+	return changes, nil
+
+}
+
+// ReadConfigDumpChanges читает файл изменений, полученный параметром -getChanges
+func ReadConfigDumpChanges(file string) (ConfigDumpChanges, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return ConfigDumpChanges{}, errors.NotExist.Wrapf(err, "read config dump changes")
+	}
+
+	return ParseConfigDumpChanges(bytes.NewBufferString(decodeV8Text(data)))
+
+}
+
+// IsEmpty изменений нет и полная выгрузка не требуется
+func (c ConfigDumpChanges) IsEmpty() bool {
+	return !c.FullDump && len(c.Changes) == 0
+}
+
+// Names возвращает полные имена объектов с указанными типами изменений.
+// Без указания типов возвращаются все объекты
+func (c ConfigDumpChanges) Names(types ...ConfigDumpChangeType) []string {
+
+	var names []string
+
+	for _, change := range c.Changes {
+
+		if len(types) > 0 && !containsChangeType(types, change.Type) {
+			continue
+		}
+
+		names = append(names, change.Name)
+
+	}
+
+	return names
+
+}
+
+// DeletedFiles возвращает файлы иерархической выгрузки удаленных объектов,
+// которые требуется удалить из каталога выгрузки
+func (c ConfigDumpChanges) DeletedFiles() []string {
+
+	var files []string
+
+	for _, name := range c.Names(CONFIG_DUMP_CHANGE_DELETED) {
+		files = append(files, DumpInfoFiles(name)...)
+	}
+
+	return files
+
+}
+
+// DumpConfigToFiles создает команду выгрузки в каталог dir только измененных и новых объектов.
+// Полные имена объектов записываются в файл списка listFile (параметр -listFile).
+// Если требуется полная выгрузка, возвращается команда полной выгрузки без файла списка.
+// Удаленные объекты не выгружаются, см. DeletedFiles.
+func (c ConfigDumpChanges) DumpConfigToFiles(dir, listFile string) (DumpConfigToFilesOptions, error) {
+
+	command := DumpConfigToFilesOptions{
+		Designer: NewDesigner(),
+		Dir:      dir,
+	}
+
+	if c.FullDump {
+		return command, nil
+	}
+
+	names := c.Names(CONFIG_DUMP_CHANGE_MODIFIED, CONFIG_DUMP_CHANGE_NEW)
+
+	if len(names) == 0 {
+		return DumpConfigToFilesOptions{}, errors.Invalid.New("no changed objects to dump").
+			WithContext("msg", "changes contain no modified or new objects")
+	}
+
+	if len(listFile) == 0 {
+		return DumpConfigToFilesOptions{}, errors.Check.New("list file must be set").
+			WithContext("msg", "argument listFile not set")
+	}
+
+	if err := WriteListFile(listFile, names); err != nil {
+		return DumpConfigToFilesOptions{}, err
+	}
+
+	return command.WithListFile(listFile), nil
+
+}
+
+func containsChangeType(types []ConfigDumpChangeType, t ConfigDumpChangeType) bool {
+
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package designer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigDumpChanges(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		text    string
+		want    ConfigDumpChanges
+		wantErr bool
+	}{
+		{
+			"changes",
+			"\ufeffModified:Catalog.Товары\r\nNew:CommonModule.Новый\r\n\r\nDeleted:Report.Старый\r\n",
+			ConfigDumpChanges{
+				Changes: []ConfigDumpChange{
+					{CONFIG_DUMP_CHANGE_MODIFIED, "Catalog.Товары"},
+					{CONFIG_DUMP_CHANGE_NEW, "CommonModule.Новый"},
+					{CONFIG_DUMP_CHANGE_DELETED, "Report.Старый"},
+				},
+			},
+			false,
+		},
+		{
+			"full dump",
+			"FullDump\n",
+			ConfigDumpChanges{FullDump: true},
+			false,
+		},
+		{"unknown type", "Renamed:Catalog.Товары", ConfigDumpChanges{}, true},
+		{"invalid line", "Catalog.Товары", ConfigDumpChanges{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConfigDumpChanges(strings.NewReader(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConfigDumpChanges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseConfigDumpChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigDumpChanges_DumpConfigToFiles(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "v8_dump_changes_")
+	defer os.RemoveAll(dir)
+
+	listFile := filepath.Join(dir, "list.txt")
+
+	changes := ConfigDumpChanges{
+		Changes: []ConfigDumpChange{
+			{CONFIG_DUMP_CHANGE_MODIFIED, "Catalog.Товары"},
+			{CONFIG_DUMP_CHANGE_NEW, "CommonModule.Новый"},
+			{CONFIG_DUMP_CHANGE_DELETED, "Report.Старый"},
+		},
+	}
+
+	got, err := changes.DumpConfigToFiles("./src", listFile)
+
+	if err != nil {
+		t.Fatalf("DumpConfigToFiles() error = %v", err)
+	}
+
+	want := []string{
+		"/DisableStartupDialogs",
+		"/DisableStartupMessages",
+		"/DumpConfigToFiles ./src",
+		"-listFile " + listFile,
+	}
+
+	if !reflect.DeepEqual(got.Values(), want) {
+		t.Errorf("DumpConfigToFiles() = %v, want %v", got.Values(), want)
+	}
+
+	data, _ := ioutil.ReadFile(listFile)
+
+	if string(data) != "Catalog.Товары\r\nCommonModule.Новый" {
+		t.Errorf("list file = %q", data)
+	}
+
+	if files := changes.DeletedFiles(); !reflect.DeepEqual(files, []string{"Reports/Старый.xml"}) {
+		t.Errorf("DeletedFiles() = %v", files)
+	}
+
+	full, err := ConfigDumpChanges{FullDump: true}.DumpConfigToFiles("./src", listFile)
+
+	if err != nil || len(full.ListFile) > 0 {
+		t.Errorf("DumpConfigToFiles() full dump = %v, error = %v", full, err)
+	}
+}
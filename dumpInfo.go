@@ -0,0 +1,288 @@
+package designer
+
+import (
+	"encoding/xml"
+	"github.com/v8platform/errors"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	DUMP_INFO_FILE_NAME = "ConfigDumpInfo.xml"
+	DUMP_INFO_NAMESPACE = "http://v8.1c.ru/8.3/xcf/dumpinfo"
+)
+
+// ConfigDumpInfo файл версий выгрузки конфигурации в XML-файлы (ConfigDumpInfo.xml)
+type ConfigDumpInfo struct {
+	XMLName xml.Name `xml:"http://v8.1c.ru/8.3/xcf/dumpinfo ConfigDumpInfo" json:"-"`
+
+	// Format формат выгрузки: Hierarchical или Plain
+	Format string `xml:"format,attr" json:"format"`
+
+	// Version версия формата выгрузки
+	Version string `xml:"version,attr" json:"version"`
+
+	Metadata []DumpInfoMetadata `xml:"ConfigVersions>Metadata" json:"metadata"`
+}
+
+// DumpInfoMetadata объект метаданных (или его подчиненный объект) из файла версий
+type DumpInfoMetadata struct {
+	// Name полное имя объекта, например Catalog.Товары.Form.ФормаЭлемента
+	Name string `xml:"name,attr" json:"name"`
+
+	// ID идентификатор объекта метаданных
+	ID string `xml:"id,attr" json:"id"`
+
+	// ConfigVersion версия объекта. Изменяется при каждом изменении объекта
+	ConfigVersion string `xml:"configVersion,attr,omitempty" json:"config_version,omitempty"`
+
+	// Metadata подчиненные объекты (реквизиты, табличные части, формы и т.д.)
+	Metadata []DumpInfoMetadata `xml:"Metadata" json:"metadata,omitempty"`
+}
+
+// ReadConfigDumpInfo читает файл версий выгрузки.
+// Если передан каталог выгрузки, читается файл ConfigDumpInfo.xml из этого каталога
+func ReadConfigDumpInfo(file string) (*ConfigDumpInfo, error) {
+
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		file = filepath.Join(file, DUMP_INFO_FILE_NAME)
+	}
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		return nil, errors.NotExist.Wrapf(err, "read config dump info")
+	}
+	defer f.Close()
+
+	dumpInfo := &ConfigDumpInfo{}
+
+	if err := xml.NewDecoder(f).Decode(dumpInfo); err != nil {
+		return nil, errors.Invalid.Wrapf(err, "read config dump info <%s>", file)
+	}
+
+	return dumpInfo, nil
+}
+
+// Objects возвращает все объекты файла версий, включая подчиненные, по полному имени
+func (d *ConfigDumpInfo) Objects() map[string]DumpInfoMetadata {
+
+	objects := make(map[string]DumpInfoMetadata)
+
+	var walk func(list []DumpInfoMetadata)
+	walk = func(list []DumpInfoMetadata) {
+		for _, m := range list {
+			objects[m.Name] = m
+			walk(m.Metadata)
+		}
+	}
+
+	walk(d.Metadata)
+
+	return objects
+}
+
+// Configuration возвращает корневой объект конфигурации
+func (d *ConfigDumpInfo) Configuration() (DumpInfoMetadata, bool) {
+
+	for _, m := range d.Metadata {
+		if strings.HasPrefix(m.Name, "Configuration.") && strings.Count(m.Name, ".") == 1 {
+			return m, true
+		}
+	}
+
+	return DumpInfoMetadata{}, false
+}
+
+// DumpInfoDiff различия двух файлов версий выгрузки
+type DumpInfoDiff struct {
+	// Added объекты, добавленные в новой выгрузке
+	Added []string `json:"added"`
+
+	// Changed объекты, у которых изменилась версия или идентификатор
+	Changed []string `json:"changed"`
+
+	// Removed объекты, удаленные в новой выгрузке
+	Removed []string `json:"removed"`
+
+	// Files файлы каталога выгрузки (в иерархическом формате), затронутые изменениями,
+	// относительно каталога выгрузки
+	Files []string `json:"files"`
+
+	// FormatChanged изменился формат или версия формата выгрузки
+	FormatChanged bool `json:"format_changed"`
+
+	// ConfigurationChanged изменился идентификатор корневого объекта конфигурации
+	ConfigurationChanged bool `json:"configuration_changed"`
+}
+
+// IsEmpty изменений не обнаружено
+func (d DumpInfoDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0 && !d.FormatChanged
+}
+
+// FullLoadRequired изменения не могут быть загружены частично (-partial):
+// изменился формат выгрузки, подменена конфигурация или удалены объекты
+func (d DumpInfoDiff) FullLoadRequired() bool {
+	return d.FormatChanged || d.ConfigurationChanged || len(d.Removed) > 0
+}
+
+// DiffConfigDumpInfo сравнивает старый и новый файлы версий выгрузки
+func DiffConfigDumpInfo(oldInfo, newInfo *ConfigDumpInfo) DumpInfoDiff {
+
+	var diff DumpInfoDiff
+
+	diff.FormatChanged = oldInfo.Format != newInfo.Format || oldInfo.Version != newInfo.Version
+
+	oldRoot, _ := oldInfo.Configuration()
+	newRoot, _ := newInfo.Configuration()
+	diff.ConfigurationChanged = oldRoot.ID != newRoot.ID
+
+	oldObjects := oldInfo.Objects()
+	newObjects := newInfo.Objects()
+
+	files := make(map[string]struct{})
+
+	addFiles := func(name string) {
+		for _, f := range DumpInfoFiles(name) {
+			files[f] = struct{}{}
+		}
+	}
+
+	for name, n := range newObjects {
+
+		o, ok := oldObjects[name]
+
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, name)
+			addFiles(name)
+		case o.ID != n.ID || o.ConfigVersion != n.ConfigVersion:
+			diff.Changed = append(diff.Changed, name)
+			addFiles(name)
+		}
+
+	}
+
+	for name := range oldObjects {
+
+		if _, ok := newObjects[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+
+	}
+
+	for f := range files {
+		diff.Files = append(diff.Files, f)
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Files)
+
+	return diff
+}
+
+var (
+	// типы подчиненных объектов, выгружаемых в отдельные файлы
+	dumpInfoFileSubTypes = map[string]bool{
+		"Form":          true,
+		"Template":      true,
+		"Command":       true,
+		"Recalculation": true,
+	}
+
+	// модули объектов, выгружаемые в каталог Ext в файлы *.bsl
+	dumpInfoModules = map[string]bool{
+		"Module":                    true,
+		"ObjectModule":              true,
+		"ManagerModule":             true,
+		"RecordSetModule":           true,
+		"ValueManagerModule":        true,
+		"CommandModule":             true,
+		"ManagedApplicationModule":  true,
+		"OrdinaryApplicationModule": true,
+		"SessionModule":             true,
+		"ExternalConnectionModule":  true,
+	}
+
+	dumpInfoPlurals = map[string]string{
+		"BusinessProcess":            "BusinessProcesses",
+		"ChartOfAccounts":            "ChartsOfAccounts",
+		"ChartOfCalculationTypes":    "ChartsOfCalculationTypes",
+		"ChartOfCharacteristicTypes": "ChartsOfCharacteristicTypes",
+		"FilterCriterion":            "FilterCriteria",
+	}
+)
+
+func dumpInfoDir(metadataType string) string {
+
+	if plural, ok := dumpInfoPlurals[metadataType]; ok {
+		return plural
+	}
+
+	return metadataType + "s"
+}
+
+// DumpInfoFiles возвращает файлы иерархической выгрузки, соответствующие объекту файла версий.
+// Для подчиненных объектов без собственного файла (реквизиты, табличные части и т.д.)
+// возвращается файл владельца.
+//
+// Например:
+//
+//	Catalog.Товары -> Catalogs/Товары.xml
+//	Catalog.Товары.Attribute.Артикул -> Catalogs/Товары.xml
+//	Catalog.Товары.ObjectModule -> Catalogs/Товары/Ext/ObjectModule.bsl
+//	Catalog.Товары.Form.ФормаЭлемента.Form -> Catalogs/Товары/Forms/ФормаЭлемента/Ext/Form.xml,
+//	  Catalogs/Товары/Forms/ФормаЭлемента/Ext/Form/Module.bsl
+func DumpInfoFiles(name string) []string {
+
+	parts := strings.Split(name, ".")
+
+	if len(parts) < 2 {
+		return nil
+	}
+
+	var dir, file string
+
+	if parts[0] == "Configuration" {
+		dir, file = "", "Configuration.xml"
+	} else {
+		dir = path.Join(dumpInfoDir(parts[0]), parts[1])
+		file = dir + ".xml"
+	}
+
+	rest := parts[2:]
+
+	for len(rest) >= 2 {
+
+		if !dumpInfoFileSubTypes[rest[0]] {
+			return []string{file}
+		}
+
+		file = path.Join(dir, dumpInfoDir(rest[0]), rest[1]) + ".xml"
+		dir = path.Join(dir, dumpInfoDir(rest[0]), rest[1])
+		rest = rest[2:]
+
+	}
+
+	if len(rest) == 0 {
+		return []string{file}
+	}
+
+	ext := path.Join(dir, "Ext")
+
+	switch {
+	case dumpInfoModules[rest[0]]:
+		return []string{path.Join(ext, rest[0]+".bsl")}
+	case rest[0] == "Form":
+		return []string{path.Join(ext, "Form.xml"), path.Join(ext, "Form", "Module.bsl")}
+	default:
+		return []string{path.Join(ext, rest[0]+".xml")}
+	}
+
+}
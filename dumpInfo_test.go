@@ -0,0 +1,82 @@
+package designer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfigDumpInfo(t *testing.T) {
+
+	oldInfo, err := ReadConfigDumpInfo(filepath.Join("tests", "fixtures", "dumpinfo", "old.xml"))
+	if err != nil {
+		t.Fatalf("ReadConfigDumpInfo() error = %v", err)
+	}
+
+	newInfo, err := ReadConfigDumpInfo(filepath.Join("tests", "fixtures", "dumpinfo", "new.xml"))
+	if err != nil {
+		t.Fatalf("ReadConfigDumpInfo() error = %v", err)
+	}
+
+	if oldInfo.Format != "Hierarchical" || oldInfo.Version != "2.10" || len(oldInfo.Objects()) != 10 {
+		t.Fatalf("ReadConfigDumpInfo() = %v", oldInfo)
+	}
+
+	want := DumpInfoDiff{
+		Added: []string{
+			"Catalog.Товары.Attribute.Штрихкод",
+			"ChartOfCharacteristicTypes.Свойства",
+		},
+		Changed: []string{
+			"Catalog.Товары.Form.ФормаЭлемента.Form",
+			"Catalog.Товары.ObjectModule",
+		},
+		Removed: []string{
+			"Document.Устаревший",
+		},
+		Files: []string{
+			"Catalogs/Товары.xml",
+			"Catalogs/Товары/Ext/ObjectModule.bsl",
+			"Catalogs/Товары/Forms/ФормаЭлемента/Ext/Form.xml",
+			"Catalogs/Товары/Forms/ФормаЭлемента/Ext/Form/Module.bsl",
+			"ChartsOfCharacteristicTypes/Свойства.xml",
+		},
+	}
+
+	got := DiffConfigDumpInfo(oldInfo, newInfo)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffConfigDumpInfo() = %v, want %v", got, want)
+	}
+
+	if !got.FullLoadRequired() || got.IsEmpty() {
+		t.Errorf("DiffConfigDumpInfo() full load required = %v, empty = %v", got.FullLoadRequired(), got.IsEmpty())
+	}
+
+	if same := DiffConfigDumpInfo(oldInfo, oldInfo); !same.IsEmpty() || same.FullLoadRequired() {
+		t.Errorf("DiffConfigDumpInfo() same = %v", same)
+	}
+}
+
+func TestDumpInfoFiles(t *testing.T) {
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Configuration.Конфигурация", []string{"Configuration.xml"}},
+		{"Configuration.Конфигурация.SessionModule", []string{"Ext/SessionModule.bsl"}},
+		{"CommonModule.ОбщегоНазначения.Module", []string{"CommonModules/ОбщегоНазначения/Ext/Module.bsl"}},
+		{"Catalog.Товары.TabularSection.Цены.Attribute.Цена", []string{"Catalogs/Товары.xml"}},
+		{"Catalog.Товары.Form.ФормаЭлемента", []string{"Catalogs/Товары/Forms/ФормаЭлемента.xml"}},
+		{"Document.Заказ.Command.Печать.CommandModule", []string{"Documents/Заказ/Commands/Печать/Ext/CommandModule.bsl"}},
+		{"Catalog.Товары.Template.Макет.Template", []string{"Catalogs/Товары/Templates/Макет/Ext/Template.xml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DumpInfoFiles(tt.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DumpInfoFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
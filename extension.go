@@ -0,0 +1,462 @@
+package designer
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/marshaler"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ExtensionFlag string
+type ExtensionScopeType string
+
+func (t ExtensionFlag) MarshalV8() (string, error) {
+	return string(t), nil
+}
+
+func (t ExtensionScopeType) MarshalV8() (string, error) {
+	return string(t), nil
+}
+
+const (
+	EXTENSION_FLAG_YES              ExtensionFlag      = "yes"
+	EXTENSION_FLAG_NO               ExtensionFlag      = "no"
+	EXTENSION_SCOPE_INFOBASE        ExtensionScopeType = "InfoBase"
+	EXTENSION_SCOPE_DATA_SEPARATION ExtensionScopeType = "DataSeparation"
+)
+
+// ExtensionFlagOf возвращает значение свойства расширения по булевому значению
+func ExtensionFlagOf(value bool) ExtensionFlag {
+
+	if value {
+		return EXTENSION_FLAG_YES
+	}
+
+	return EXTENSION_FLAG_NO
+}
+
+///DeleteCfg [-Extension <имя расширения>] [-AllExtensions]
+//— удаление расширений конфигурации.
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /DeleteCfg -Extension ИсправлениеОшибок
+type DeleteCfgOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/DeleteCfg" json:"-"`
+
+	//-Extension <имя расширения> — удаление указанного расширения.
+	Extension string `v8:"-Extension, optional" json:"extension"`
+
+	//-AllExtensions — удаление всех расширений конфигурации.
+	AllExtensions bool `v8:"-AllExtensions, optional" json:"all_extensions"`
+}
+
+func (o DeleteCfgOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: должен быть указан ровно один из параметров -Extension и -AllExtensions
+func (o DeleteCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Extension) == 0 && !o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension or all extensions must be set").
+			WithContext("msg", "field Extension or AllExtensions not set"))
+	}
+
+	if len(o.Extension) > 0 && o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension and all extensions are mutually exclusive").
+			WithContext("msg", "fields Extension and AllExtensions set together"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+// NewDeleteCfg создает команду удаления расширения.
+// Без указания имени расширения удаляются все расширения конфигурации
+func NewDeleteCfg(extension ...string) DeleteCfgOptions {
+
+	command := DeleteCfgOptions{
+		Designer:      NewDesigner(),
+		AllExtensions: true,
+	}
+
+	if len(extension) > 0 && len(extension[0]) > 0 {
+		command.Extension = extension[0]
+		command.AllExtensions = false
+	}
+
+	return command
+
+}
+
+///DumpDBCfgList [-Extension <имя расширения>] [-AllExtensions]
+//— вывод списка расширений конфигурации базы данных в файл служебных сообщений (/Out).
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /DumpDBCfgList -AllExtensions /Out "D:\extensions.txt"
+type DumpDBCfgListOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/DumpDBCfgList" json:"-"`
+
+	//-Extension <имя расширения> — вывести только указанное расширение.
+	Extension string `v8:"-Extension, optional" json:"extension"`
+
+	//-AllExtensions — вывести все расширения конфигурации.
+	AllExtensions bool `v8:"-AllExtensions, optional" json:"all_extensions"`
+}
+
+func (o DumpDBCfgListOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: -Extension и -AllExtensions взаимоисключающие
+func (o DumpDBCfgListOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Extension) > 0 && o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension and all extensions are mutually exclusive").
+			WithContext("msg", "fields Extension and AllExtensions set together"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+// NewDumpDBCfgList создает команду вывода списка всех расширений конфигурации базы данных
+func NewDumpDBCfgList() DumpDBCfgListOptions {
+
+	return DumpDBCfgListOptions{
+		Designer:      NewDesigner(),
+		AllExtensions: true,
+	}
+
+}
+
+// ExtensionInfo расширение конфигурации из списка /DumpDBCfgList
+type ExtensionInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ParseDBCfgList разбирает список расширений, выведенный командой /DumpDBCfgList.
+// Каждое расширение выводится отдельной строкой: имя и, при наличии, версия
+// через пробел или в скобках. Строки-заголовки, оканчивающиеся двоеточием, пропускаются.
+func ParseDBCfgList(r io.Reader) ([]ExtensionInfo, error) {
+
+	var list []ExtensionInfo
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+
+		if len(line) == 0 || strings.HasSuffix(line, ":") {
+			continue
+		}
+
+		info := ExtensionInfo{Name: line}
+
+		if i := strings.IndexAny(line, " \t("); i > 0 {
+			info.Name = line[:i]
+			info.Version = strings.Trim(strings.TrimSpace(line[i:]), "()")
+		}
+
+		list = append(list, info)
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+
+}
+
+// ReadDBCfgList читает список расширений из файла служебных сообщений
+func ReadDBCfgList(file string) ([]ExtensionInfo, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, errors.NotExist.Wrapf(err, "read extensions list")
+	}
+
+	return ParseDBCfgList(bytes.NewBufferString(decodeV8Text(data)))
+
+}
+
+///ManageCfgExtensions -Extension <имя расширения> [-Active <yes|no>] [-SafeMode <yes|no>]
+//[-SecurityProfileName <имя профиля>] [-UnsafeActionProtection <yes|no>] [-Scope <область действия>]
+//— изменение свойств расширения конфигурации.
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /ManageCfgExtensions -Extension ИсправлениеОшибок -Active yes -SafeMode no
+type ManageCfgExtensionsOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	command struct{} `v8:"/ManageCfgExtensions" json:"-"`
+
+	//-Extension <имя расширения> — имя изменяемого расширения.
+	Extension string `v8:"-Extension" json:"extension"`
+
+	//-Active <yes|no> — расширение активно.
+	Active ExtensionFlag `v8:"-Active, optional" json:"active"`
+
+	//-SafeMode <yes|no> — расширение работает в безопасном режиме.
+	SafeMode ExtensionFlag `v8:"-SafeMode, optional" json:"safe_mode"`
+
+	//-SecurityProfileName <имя профиля> — имя профиля безопасности, под управлением которого работает расширение.
+	SecurityProfileName string `v8:"-SecurityProfileName, optional" json:"security_profile_name"`
+
+	//-UnsafeActionProtection <yes|no> — защита от опасных действий.
+	UnsafeActionProtection ExtensionFlag `v8:"-UnsafeActionProtection, optional" json:"unsafe_action_protection"`
+
+	//-Scope <область действия> — область действия расширения. Возможные значения:
+	//	InfoBase — информационная база,
+	//	DataSeparation — область данных.
+	Scope ExtensionScopeType `v8:"-Scope, optional" json:"scope"`
+}
+
+func (o ManageCfgExtensionsOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: имя расширения и хотя бы одно изменяемое свойство обязательны,
+// профиль безопасности не используется при отключенном безопасном режиме
+func (o ManageCfgExtensionsOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Extension) == 0 {
+		multierror.Append(&err, errors.Check.New("extension must be set").
+			WithContext("msg", "field Extension not set"))
+	}
+
+	if len(o.Active) == 0 && len(o.SafeMode) == 0 && len(o.SecurityProfileName) == 0 &&
+		len(o.UnsafeActionProtection) == 0 && len(o.Scope) == 0 {
+		multierror.Append(&err, errors.Check.New("at least one extension property must be set").
+			WithContext("msg", "fields Active, SafeMode, SecurityProfileName, UnsafeActionProtection and Scope not set"))
+	}
+
+	if len(o.SecurityProfileName) > 0 && o.SafeMode == EXTENSION_FLAG_NO {
+		multierror.Append(&err, errors.Check.New("security profile requires safe mode").
+			WithContext("msg", "field SecurityProfileName set with SafeMode no"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o ManageCfgExtensionsOptions) WithActive(active bool) ManageCfgExtensionsOptions {
+
+	newO := o
+	newO.Active = ExtensionFlagOf(active)
+	return newO
+
+}
+
+func (o ManageCfgExtensionsOptions) WithSafeMode(safeMode bool, securityProfileName ...string) ManageCfgExtensionsOptions {
+
+	newO := o
+	newO.SafeMode = ExtensionFlagOf(safeMode)
+	newO.SecurityProfileName = ""
+
+	if safeMode && len(securityProfileName) > 0 {
+		newO.SecurityProfileName = securityProfileName[0]
+	}
+
+	return newO
+
+}
+
+func (o ManageCfgExtensionsOptions) WithUnsafeActionProtection(protection bool) ManageCfgExtensionsOptions {
+
+	newO := o
+	newO.UnsafeActionProtection = ExtensionFlagOf(protection)
+	return newO
+
+}
+
+func (o ManageCfgExtensionsOptions) WithScope(scope ExtensionScopeType) ManageCfgExtensionsOptions {
+
+	newO := o
+	newO.Scope = scope
+	return newO
+
+}
+
+// NewManageCfgExtensions создает команду изменения свойств расширения конфигурации
+func NewManageCfgExtensions(extension string) ManageCfgExtensionsOptions {
+
+	return ManageCfgExtensionsOptions{
+		Designer:  NewDesigner(),
+		Extension: extension,
+	}
+
+}
+
+// ExtensionApplyProblem проблема применения расширения, выявленная командой /CheckCanApplyConfigurationExtensions
+type ExtensionApplyProblem struct {
+	// Extension имя расширения. Пустое, если сообщение не относится к конкретному расширению
+	Extension string `json:"extension,omitempty"`
+
+	// Zone значение разделителя (область данных), для которого выявлена проблема
+	Zone string `json:"zone,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// CheckCanApplyExtensionsResult результат проверки применимости расширений
+type CheckCanApplyExtensionsResult struct {
+	CanApply bool                    `json:"can_apply"`
+	Problems []ExtensionApplyProblem `json:"problems"`
+}
+
+var (
+	// Расширение "ИсправлениеОшибок" (область 12): Не найден объект основной конфигурации Справочник.Товары
+	reExtensionProblem = regexp.MustCompile(
+		`^(?i:Расширение(?: конфигурации)?|Extension)\s+"?([^"():]+?)"?\s*(?:\((?i:область(?: данных)?|zone)\s*([^)]*)\))?\s*:\s*(.*)$`)
+
+	// canApplyMessages сообщения об успешной проверке. Сравниваются со всей строкой
+	// (или с текстом сообщения по расширению), чтобы не пропустить "не может быть применено"
+	canApplyMessages = []string{
+		"Расширения конфигурации могут быть применены",
+		"Расширение конфигурации может быть применено",
+		"Расширения могут быть применены",
+		"Расширение может быть применено",
+		"могут быть применены",
+		"может быть применено",
+		"Configuration extensions can be applied",
+		"Extensions can be applied",
+		"Extension can be applied",
+		"can be applied",
+	}
+)
+
+// ParseCheckCanApplyExtensions разбирает файл служебных сообщений (/Out) и код возврата
+// команды /CheckCanApplyConfigurationExtensions.
+// Строки, не относящиеся к конкретному расширению, дополняют сообщение предыдущей проблемы.
+func ParseCheckCanApplyExtensions(r io.Reader, exitCode int) (CheckCanApplyExtensionsResult, error) {
+
+	var problems []ExtensionApplyProblem
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if m := reExtensionProblem.FindStringSubmatch(line); m != nil {
+			if isCanApplyMessage(m[3]) {
+				continue
+			}
+			problems = append(problems, ExtensionApplyProblem{
+				Extension: strings.TrimSpace(m[1]),
+				Zone:      strings.TrimSpace(m[2]),
+				Message:   strings.TrimSpace(m[3]),
+			})
+			continue
+		}
+
+		if isCanApplyMessage(line) {
+			continue
+		}
+
+		if n := len(problems); n > 0 {
+			if len(problems[n-1].Message) > 0 {
+				problems[n-1].Message += "\n"
+			}
+			problems[n-1].Message += line
+			continue
+		}
+
+		problems = append(problems, ExtensionApplyProblem{Message: line})
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return CheckCanApplyExtensionsResult{}, err
+	}
+
+	if exitCode != 0 && len(problems) == 0 {
+		problems = append(problems, ExtensionApplyProblem{
+			Message: "extensions can not be applied, exit code " + strconv.Itoa(exitCode),
+		})
+	}
+
+	return CheckCanApplyExtensionsResult{
+		CanApply: exitCode == 0 && len(problems) == 0,
+		Problems: problems,
+	}, nil
+
+}
+
+// ReadCheckCanApplyExtensions читает результат проверки применимости расширений из файла служебных сообщений
+func ReadCheckCanApplyExtensions(file string, exitCode int) (CheckCanApplyExtensionsResult, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return CheckCanApplyExtensionsResult{}, errors.NotExist.Wrapf(err, "read check extensions result")
+	}
+
+	return ParseCheckCanApplyExtensions(bytes.NewBufferString(decodeV8Text(data)), exitCode)
+
+}
+
+func isCanApplyMessage(s string) bool {
+
+	s = strings.TrimSuffix(strings.TrimSpace(s), ".")
+
+	for _, msg := range canApplyMessages {
+		if strings.EqualFold(s, msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(s string, substrings []string) bool {
+
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+
+	return false
+}
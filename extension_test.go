@@ -0,0 +1,187 @@
+package designer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtensionOptions_Values(t *testing.T) {
+
+	tests := []struct {
+		name string
+		cmd  command
+		want []string
+	}{
+		{
+			"delete extension",
+			NewDeleteCfg("ИсправлениеОшибок"),
+			[]string{"/DisableStartupDialogs", "/DisableStartupMessages", "/DeleteCfg", "-Extension ИсправлениеОшибок"},
+		},
+		{
+			"delete all",
+			NewDeleteCfg(),
+			[]string{"/DisableStartupDialogs", "/DisableStartupMessages", "/DeleteCfg", "-AllExtensions"},
+		},
+		{
+			"list",
+			NewDumpDBCfgList(),
+			[]string{"/DisableStartupDialogs", "/DisableStartupMessages", "/DumpDBCfgList", "-AllExtensions"},
+		},
+		{
+			"manage",
+			NewManageCfgExtensions("ИсправлениеОшибок").
+				WithActive(true).
+				WithSafeMode(true, "profile").
+				WithUnsafeActionProtection(false).
+				WithScope(EXTENSION_SCOPE_DATA_SEPARATION),
+			[]string{
+				"/DisableStartupDialogs",
+				"/DisableStartupMessages",
+				"/ManageCfgExtensions",
+				"-Extension ИсправлениеОшибок",
+				"-Active yes",
+				"-SafeMode yes",
+				"-SecurityProfileName profile",
+				"-UnsafeActionProtection no",
+				"-Scope DataSeparation",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtensionOptions_Check(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cmd     command
+		wantErr bool
+	}{
+		{"delete", NewDeleteCfg("ext"), false},
+		{"delete nothing", DeleteCfgOptions{}, true},
+		{"delete both", DeleteCfgOptions{Extension: "ext", AllExtensions: true}, true},
+		{"manage", NewManageCfgExtensions("ext").WithActive(false), false},
+		{"manage without properties", NewManageCfgExtensions("ext"), true},
+		{"manage profile without safe mode", ManageCfgExtensionsOptions{Extension: "ext", SafeMode: EXTENSION_FLAG_NO, SecurityProfileName: "p"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDBCfgList(t *testing.T) {
+
+	got, err := ParseDBCfgList(strings.NewReader("\ufeffРасширения конфигурации:\r\nИсправлениеОшибок 1.0.2\r\n\r\nОтчеты (2.1)\r\nБезВерсии\r\n"))
+
+	if err != nil {
+		t.Fatalf("ParseDBCfgList() error = %v", err)
+	}
+
+	want := []ExtensionInfo{
+		{Name: "ИсправлениеОшибок", Version: "1.0.2"},
+		{Name: "Отчеты", Version: "2.1"},
+		{Name: "БезВерсии"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDBCfgList() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckCanApplyExtensionsOptions_Values(t *testing.T) {
+
+	tests := []struct {
+		name string
+		cmd  CheckCanApplyExtensionsOptions
+		want []string
+	}{
+		{
+			"all zones",
+			CheckCanApplyExtensionsOptions{}.WithExtension("ИсправлениеОшибок").WithAllZones(),
+			[]string{"/CheckCanApplyConfigurationExtensions", "-Extension ИсправлениеОшибок", "-AllZones"},
+		},
+		{
+			"zones",
+			CheckCanApplyExtensionsOptions{}.WithAllZones().WithZones("1", "2"),
+			[]string{"/CheckCanApplyConfigurationExtensions", "-Z 1,2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCheckCanApplyExtensions(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		output   string
+		exitCode int
+		want     CheckCanApplyExtensionsResult
+	}{
+		{
+			"success",
+			"Расширения конфигурации могут быть применены\r\n",
+			0,
+			CheckCanApplyExtensionsResult{CanApply: true},
+		},
+		{
+			"problems",
+			"Расширение \"ИсправлениеОшибок\" (область 12): Не найден объект Справочник.Товары\r\n" +
+				"  Реквизит: Артикул\r\n" +
+				"Extension Reports: Incompatible configuration version\r\n",
+			1,
+			CheckCanApplyExtensionsResult{
+				Problems: []ExtensionApplyProblem{
+					{Extension: "ИсправлениеОшибок", Zone: "12", Message: "Не найден объект Справочник.Товары\nРеквизит: Артикул"},
+					{Extension: "Reports", Message: "Incompatible configuration version"},
+				},
+			},
+		},
+		{
+			"failure message with success phrase",
+			"Расширение \"ИсправлениеОшибок\": не может быть применено, так как изменилась конфигурация\r\n" +
+				"Расширение \"Отчеты\": может быть применено\r\n",
+			1,
+			CheckCanApplyExtensionsResult{
+				Problems: []ExtensionApplyProblem{
+					{Extension: "ИсправлениеОшибок", Message: "не может быть применено, так как изменилась конфигурация"},
+				},
+			},
+		},
+		{
+			"exit code only",
+			"",
+			1,
+			CheckCanApplyExtensionsResult{
+				Problems: []ExtensionApplyProblem{{Message: "extensions can not be applied, exit code 1"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCheckCanApplyExtensions(strings.NewReader(tt.output), tt.exitCode)
+			if err != nil {
+				t.Fatalf("ParseCheckCanApplyExtensions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCheckCanApplyExtensions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
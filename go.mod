@@ -9,5 +9,6 @@ require (
 	github.com/v8platform/errors v0.1.0
 	github.com/v8platform/marshaler v0.1.1
 	github.com/v8platform/runner v0.3.1
-
+	golang.org/x/text v0.3.3
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
@@ -0,0 +1,359 @@
+// Пакет чтения и записи списка информационных баз (ibases.v8i)
+package ibases
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/v8platform/designer"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	KEY_CONNECT                 = "Connect"
+	KEY_ID                      = "ID"
+	KEY_ORDER_IN_LIST           = "OrderInList"
+	KEY_FOLDER                  = "Folder"
+	KEY_ORDER_IN_TREE           = "OrderInTree"
+	KEY_EXTERNAL                = "External"
+	KEY_CLIENT_CONNECTION_SPEED = "ClientConnectionSpeed"
+	KEY_APP                     = "App"
+	KEY_WA                      = "WA"
+	KEY_VERSION                 = "Version"
+	KEY_DEFAULT_APP             = "DefaultApp"
+
+	CONNECTION_SPEED_NORMAL = "Normal"
+	CONNECTION_SPEED_LOW    = "Low"
+
+	ROOT_FOLDER = "/"
+)
+
+var bom = []byte{0xEF, 0xBB, 0xBF}
+
+// Param параметр информационной базы в виде пары ключ=значение
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Entry информационная база из списка.
+// Параметры хранятся в исходном порядке, неизвестные ключи сохраняются при записи
+type Entry struct {
+	// Name наименование информационной базы (имя секции)
+	Name   string
+	Params []Param
+}
+
+// List список информационных баз
+type List struct {
+	Entries []*Entry
+}
+
+// DefaultFile возвращает путь к списку информационных баз текущего пользователя
+func DefaultFile() string {
+
+	appData := os.Getenv("APPDATA")
+
+	if len(appData) == 0 {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, ".1C", "1cestart", "ibases.v8i")
+	}
+
+	return filepath.Join(appData, "1C", "1CEStart", "ibases.v8i")
+}
+
+// NewEntry создает информационную базу с указанной строкой соединения в корневой папке списка
+func NewEntry(name string, ib runner.Infobase) *Entry {
+
+	e := &Entry{Name: name}
+	e.SetInfobase(ib)
+	e.Set(KEY_FOLDER, ROOT_FOLDER)
+	return e
+}
+
+// Get возвращает значение параметра. Ключ сравнивается без учета регистра
+func (e *Entry) Get(key string) (string, bool) {
+
+	for _, p := range e.Params {
+		if strings.EqualFold(p.Key, key) {
+			return p.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Value возвращает значение параметра или пустую строку
+func (e *Entry) Value(key string) string {
+
+	v, _ := e.Get(key)
+	return v
+}
+
+// Set устанавливает значение параметра, добавляя его в конец, если параметр отсутствует
+func (e *Entry) Set(key, value string) {
+
+	for i, p := range e.Params {
+		if strings.EqualFold(p.Key, key) {
+			e.Params[i].Value = value
+			return
+		}
+	}
+
+	e.Params = append(e.Params, Param{Key: key, Value: value})
+}
+
+// Delete удаляет параметр
+func (e *Entry) Delete(key string) {
+
+	params := e.Params[:0]
+
+	for _, p := range e.Params {
+		if !strings.EqualFold(p.Key, key) {
+			params = append(params, p)
+		}
+	}
+
+	e.Params = params
+}
+
+func (e *Entry) Connect() string {
+	return e.Value(KEY_CONNECT)
+}
+
+func (e *Entry) ID() string {
+	return e.Value(KEY_ID)
+}
+
+func (e *Entry) Folder() string {
+	return e.Value(KEY_FOLDER)
+}
+
+func (e *Entry) ClientConnectionSpeed() string {
+	return e.Value(KEY_CLIENT_CONNECTION_SPEED)
+}
+
+func (e *Entry) Version() string {
+	return e.Value(KEY_VERSION)
+}
+
+func (e *Entry) OrderInList() int64 {
+	return e.intValue(KEY_ORDER_IN_LIST)
+}
+
+func (e *Entry) OrderInTree() int64 {
+	return e.intValue(KEY_ORDER_IN_TREE)
+}
+
+// External признак базы, добавленной из общего списка информационных баз
+func (e *Entry) External() bool {
+	return e.intValue(KEY_EXTERNAL) != 0
+}
+
+func (e *Entry) intValue(key string) int64 {
+
+	v, _ := strconv.ParseInt(strings.TrimSpace(e.Value(key)), 10, 64)
+	return v
+}
+
+// Infobase возвращает типизированную информационную базу по строке соединения Connect
+func (e *Entry) Infobase() (runner.Infobase, error) {
+
+	connect, ok := e.Get(KEY_CONNECT)
+
+	if !ok {
+		return nil, errors.Invalid.Newf("infobase <%s> has no connection string", e.Name)
+	}
+
+	ib, err := designer.ParseConnectionString(connect)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "infobase <%s>", e.Name)
+	}
+
+	return ib, nil
+}
+
+// SetInfobase устанавливает строку соединения Connect по типизированной информационной базе
+func (e *Entry) SetInfobase(ib runner.Infobase) {
+
+	e.Set(KEY_CONNECT, connectString(ib))
+}
+
+// connectString возвращает строку соединения без параметра запуска /IBConnectionString
+func connectString(ib runner.Infobase) string {
+
+	if s, ok := ib.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	parsed, err := designer.ParseConnectionString(ib.ConnectionString())
+
+	if err != nil {
+		return ib.ConnectionString()
+	}
+
+	return parsed.(fmt.Stringer).String()
+}
+
+// Find возвращает информационную базу по наименованию
+func (l *List) Find(name string) *Entry {
+
+	for _, e := range l.Entries {
+		if e.Name == name {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// FindByID возвращает информационную базу по идентификатору
+func (l *List) FindByID(id string) *Entry {
+
+	for _, e := range l.Entries {
+		if strings.EqualFold(e.ID(), id) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Add добавляет информационную базу в конец списка.
+// Информационная база с тем же наименованием заменяется
+func (l *List) Add(entry *Entry) {
+
+	for i, e := range l.Entries {
+		if e.Name == entry.Name {
+			l.Entries[i] = entry
+			return
+		}
+	}
+
+	l.Entries = append(l.Entries, entry)
+}
+
+// Remove удаляет информационную базу из списка
+func (l *List) Remove(name string) bool {
+
+	for i, e := range l.Entries {
+		if e.Name == name {
+			l.Entries = append(l.Entries[:i], l.Entries[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse разбирает список информационных баз
+func Parse(r io.Reader) (*List, error) {
+
+	list := &List{}
+
+	var entry *Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	lineNum := 0
+
+	for scanner.Scan() {
+
+		line := scanner.Text()
+
+		if lineNum == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		lineNum++
+
+		line = strings.TrimSpace(line)
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			entry = &Entry{Name: line[1 : len(line)-1]}
+			list.Entries = append(list.Entries, entry)
+			continue
+		}
+
+		if entry == nil {
+			return nil, errors.Invalid.Newf("line %d: parameter outside of section", lineNum)
+		}
+
+		i := strings.IndexByte(line, '=')
+
+		if i < 0 {
+			return nil, errors.Invalid.Newf("line %d: invalid parameter <%s>", lineNum, line)
+		}
+
+		entry.Params = append(entry.Params, Param{
+			Key:   strings.TrimSpace(line[:i]),
+			Value: line[i+1:],
+		})
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ReadFile читает файл списка информационных баз
+func ReadFile(file string) (*List, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data))
+}
+
+// Write выводит список информационных баз в формате платформы (UTF-8 с BOM, перевод строки CRLF)
+func (l *List) Write(w io.Writer) error {
+
+	buf := bufio.NewWriter(w)
+	_, _ = buf.Write(bom)
+
+	for _, e := range l.Entries {
+
+		_, _ = buf.WriteString("[" + e.Name + "]\r\n")
+
+		for _, p := range e.Params {
+			_, _ = buf.WriteString(p.Key + "=" + p.Value + "\r\n")
+		}
+
+	}
+
+	return buf.Flush()
+}
+
+// WriteFile записывает файл списка информационных баз
+func (l *List) WriteFile(file string) error {
+
+	var buf bytes.Buffer
+
+	if err := l.Write(&buf); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, buf.Bytes(), 0644)
+}
@@ -0,0 +1,99 @@
+package ibases
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/v8platform/designer"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func fixture() string {
+	return filepath.Join("..", "tests", "fixtures", "ibases", "ibases.v8i")
+}
+
+func TestReadFile(t *testing.T) {
+
+	list, err := ReadFile(fixture())
+	require.NoError(t, err)
+	require.Len(t, list.Entries, 3)
+
+	e := list.Find("Бухгалтерия")
+	require.NotNil(t, e)
+	require.Equal(t, "8e7b0c3a-6c8a-4d2e-9f0e-1b2c3d4e5f60", e.ID())
+	require.Equal(t, int64(16384), e.OrderInList())
+	require.Equal(t, ROOT_FOLDER, e.Folder())
+	require.Equal(t, CONNECTION_SPEED_NORMAL, e.ClientConnectionSpeed())
+	require.False(t, e.External())
+	require.Equal(t, "8.3.18", e.Value("DefaultVersion"))
+
+	require.Equal(t, "/Кадры", list.FindByID("1F2E3D4C-5B6A-4978-8695-A4B3C2D1E0F9").Folder())
+}
+
+func TestEntry_Infobase(t *testing.T) {
+
+	list, err := ReadFile(fixture())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		want interface{}
+	}{
+		{"Бухгалтерия", designer.NewFileInfobase(`D:\Bases\Бухгалтерия`)},
+		{"ЗУП на сервере", designer.NewServerInfobase("app01:1541", "zup")},
+		{"Веб", designer.NewWSInfobase("http://web/base").WithWSCredentials("web", "pass")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ib, err := list.Find(tt.name).Infobase()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ib)
+		})
+	}
+
+	_, err = (&Entry{Name: "empty"}).Infobase()
+	require.Error(t, err)
+}
+
+func TestList_Write(t *testing.T) {
+
+	data, err := ioutil.ReadFile(fixture())
+	require.NoError(t, err)
+
+	list, err := Parse(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, list.Write(&buf))
+
+	got, err := Parse(&buf)
+	require.NoError(t, err)
+	require.Equal(t, list, got)
+}
+
+func TestList_AddAndRemove(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "v8_ibases_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "1CEStart", "ibases.v8i")
+
+	list := &List{}
+	list.Add(NewEntry("test", designer.NewFileInfobase(`C:\base`).WithCredentials("Admin", "")))
+	require.NoError(t, list.WriteFile(file))
+
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "\ufeff[test]\r\nConnect=File=\"C:\\base\";Usr=\"Admin\";Pwd=\"\";\r\nFolder=/\r\n", string(data))
+
+	got, err := ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, list, got)
+
+	require.True(t, got.Remove("test"))
+	require.False(t, got.Remove("test"))
+	require.Empty(t, got.Entries)
+}
@@ -1,6 +1,8 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 )
 
@@ -18,6 +20,22 @@ func (d DumpIBOptions) Values() []string {
 	return v
 }
 
+// Check проверяет параметры команды: файл выгрузки обязателен
+func (d DumpIBOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	if len(d.File) == 0 {
+		multierror.Append(&err, errors.Check.New("dump file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 // /RestoreIB <имя файла>
 // — загрузка информационной базы в командном режиме.
 // Если файл информационной базы отсутствует в указанном каталоге, будет создана новая информационная база.
@@ -32,3 +50,19 @@ func (d RestoreIBOptions) Values() []string {
 	v, _ := marshaler.Marshal(d)
 	return v
 }
+
+// Check проверяет параметры команды: файл загрузки обязателен
+func (d RestoreIBOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	if len(d.File) == 0 {
+		multierror.Append(&err, errors.Check.New("restore file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
@@ -0,0 +1,236 @@
+package designer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	JOB_KEY_COMMAND  = "command"
+	JOB_KEY_NAME     = "name"
+	JOB_KEY_INFOBASE = "infobase"
+	JOB_KEY_USER     = "user"
+	JOB_KEY_PASSWORD = "password"
+)
+
+// Job задание: команда пакета с параметрами из файла описания.
+//
+// Файл задания (YAML или JSON) содержит объект или список объектов вида:
+//
+//	command: LoadConfigFromFiles        # имя команды, см. Lookup
+//	name: load                          # наименование задания, необязательно
+//	infobase: File="./ib"               # строка соединения, см. ParseConnectionString
+//	user: admin                         # пользователь информационной базы, необязательно
+//	password: ""
+//	dir: ./src                          # параметры команды по тегам json
+//	update_db_cfg:                      # вложенная команда /UpdateDBCfg
+//	  dynamic: true
+//
+// Параметры команды заполняются по тегам json поверх значений по умолчанию (NewDesigner),
+// неизвестные параметры считаются ошибкой
+type Job struct {
+	Name string `json:"name"`
+
+	Infobase runner.Infobase `json:"-"`
+
+	User     string `json:"user"`
+	Password string `json:"password"`
+
+	Command runner.Command `json:"-"`
+
+	// Run функция запуска команды. По умолчанию запуск через runner
+	Run RunFunc `json:"-"`
+}
+
+// ParseJobs разбирает файл заданий в формате YAML или JSON (JSON является подмножеством YAML)
+func ParseJobs(r io.Reader) ([]Job, error) {
+
+	var raw interface{}
+
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, errors.Invalid.Wrapf(err, "parse jobs")
+	}
+
+	var items []interface{}
+
+	switch v := raw.(type) {
+	case []interface{}:
+		items = v
+	case map[string]interface{}:
+		items = []interface{}{v}
+	default:
+		return nil, errors.Invalid.New("jobs must be an object or a list of objects")
+	}
+
+	jobs := make([]Job, 0, len(items))
+
+	for i, item := range items {
+
+		m, ok := item.(map[string]interface{})
+
+		if !ok {
+			return nil, errors.Invalid.Newf("job %d: must be an object", i+1)
+		}
+
+		job, err := decodeJob(m)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "job %d", i+1)
+		}
+
+		jobs = append(jobs, job)
+
+	}
+
+	return jobs, nil
+}
+
+// ReadJobFile читает файл заданий
+func ReadJobFile(file string) ([]Job, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, errors.NotExist.Wrapf(err, "read job file")
+	}
+
+	return ParseJobs(bytes.NewReader(data))
+}
+
+// Check проверяет параметры команды задания
+func (j Job) Check() error {
+
+	if j.Command == nil {
+		return errors.Check.Newf("job <%s> has no command", j.Name)
+	}
+
+	if j.Infobase == nil && j.Command.Command() != COMMAND_CREATEINFOBASE {
+		return errors.Check.Newf("job <%s>: infobase must be set", j.Name).
+			WithContext("msg", "field infobase not set")
+	}
+
+	return j.Command.Check()
+}
+
+// Execute проверяет и выполняет команду задания.
+// Ошибка выполнения классифицируется по сообщениям конфигуратора, см. Classify
+func (j Job) Execute(ctx context.Context, opts ...interface{}) error {
+
+	if err := j.Check(); err != nil {
+		return err
+	}
+
+	runOpts := append([]interface{}{runner.WithCredentials(j.User, j.Password)}, opts...)
+
+	captured, err := runCapture(ctx, j.Run, j.Infobase, j.Command, runOpts)
+
+	if err != nil {
+		return err
+	}
+
+	return captured.Err
+}
+
+// Step возвращает шаг конвейера для задания.
+// Пользователь задания передается параметрами запуска шага
+func (j Job) Step() PipelineStep {
+
+	return NewStep(j.Name, j.Command).
+		WithOptions(runner.WithCredentials(j.User, j.Password))
+}
+
+func decodeJob(m map[string]interface{}) (Job, error) {
+
+	var job Job
+
+	name, ok := m[JOB_KEY_COMMAND].(string)
+
+	if !ok || len(name) == 0 {
+		return Job{}, errors.Invalid.New("command not set")
+	}
+
+	what, err := NewCommand(name)
+
+	if err != nil {
+		return Job{}, err
+	}
+
+	params := make(map[string]interface{}, len(m))
+
+	for key, value := range m {
+
+		switch key {
+		case JOB_KEY_COMMAND:
+		case JOB_KEY_NAME:
+			job.Name, _ = value.(string)
+		case JOB_KEY_USER:
+			job.User, _ = value.(string)
+		case JOB_KEY_PASSWORD:
+			job.Password, _ = value.(string)
+		case JOB_KEY_INFOBASE:
+			connect, _ := value.(string)
+			if job.Infobase, err = ParseConnectionString(connect); err != nil {
+				return Job{}, err
+			}
+		default:
+			params[key] = value
+		}
+
+	}
+
+	if len(job.Name) == 0 {
+		job.Name = CommandName(what)
+	}
+
+	data, err := json.Marshal(params)
+
+	if err != nil {
+		return Job{}, errors.Invalid.Wrapf(err, "command <%s> params", name)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(what); err != nil {
+		return Job{}, errors.Invalid.Wrapf(err, "command <%s> params", name)
+	}
+
+	job.Command = what
+
+	return job, nil
+}
+
+// NewPipelineFromJobs создает конвейер из заданий.
+// Все задания должны выполняться в одной информационной базе
+func NewPipelineFromJobs(jobs []Job) (*Pipeline, error) {
+
+	p := &Pipeline{}
+
+	for _, job := range jobs {
+
+		if job.Infobase != nil {
+
+			if p.Infobase != nil && !strings.EqualFold(p.Infobase.ConnectionString(), job.Infobase.ConnectionString()) {
+				return nil, errors.Invalid.Newf("job <%s>: pipeline jobs must use one infobase", job.Name)
+			}
+
+			p.Infobase = job.Infobase
+
+		}
+
+		p.Add(job.Step())
+
+	}
+
+	return p, nil
+}
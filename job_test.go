@@ -0,0 +1,181 @@
+package designer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+
+	names := Commands()
+
+	if len(names) == 0 {
+		t.Fatal("Commands() is empty")
+	}
+
+	for _, name := range names {
+
+		what, err := NewCommand(name)
+		if err != nil {
+			t.Errorf("NewCommand(%s) error = %v", name, err)
+			continue
+		}
+
+		if got := CommandName(what); got != name {
+			t.Errorf("CommandName() = %v, want %v", got, name)
+		}
+
+	}
+
+	for _, name := range []string{"UpdateDBCfg", "updatedbcfg", "UpdateDBCfgOptions"} {
+		if what, err := NewCommand(name); err != nil || reflect.TypeOf(what) != reflect.TypeOf(&UpdateDBCfgOptions{}) {
+			t.Errorf("NewCommand(%s) = %T, %v", name, what, err)
+		}
+	}
+
+	if _, err := NewCommand("Unknown"); err == nil {
+		t.Errorf("NewCommand(Unknown) error = nil, want error")
+	}
+
+}
+
+func TestReadJobFile(t *testing.T) {
+
+	jobs, err := ReadJobFile(filepath.Join("tests", "fixtures", "jobs", "jobs.yaml"))
+	if err != nil {
+		t.Fatalf("ReadJobFile() error = %v", err)
+	}
+
+	want := []struct {
+		name   string
+		user   string
+		values []string
+	}{
+		{
+			"load",
+			"admin",
+			[]string{
+				"/DisableStartupDialogs",
+				"/DisableStartupMessages",
+				"/LoadConfigFromFiles ./src",
+				"/UpdateDBCfg",
+				"-Server",
+			},
+		},
+		{
+			"RepositoryReport",
+			"",
+			[]string{
+				"/DisableStartupDialogs",
+				"/DisableStartupMessages",
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN repo-user",
+				"/ConfigurationRepositoryReport ./report.mxl",
+			},
+		},
+	}
+
+	if len(jobs) != len(want) {
+		t.Fatalf("ReadJobFile() len = %v, want %v", len(jobs), len(want))
+	}
+
+	for i, job := range jobs {
+
+		if job.Name != want[i].name || job.User != want[i].user {
+			t.Errorf("job %d = %v/%v, want %v/%v", i, job.Name, job.User, want[i].name, want[i].user)
+		}
+
+		if got := job.Command.Values(); !reflect.DeepEqual(got, want[i].values) {
+			t.Errorf("job %d Values() = %v, want %v", i, got, want[i].values)
+		}
+
+		if got := job.Infobase.ConnectionString(); got != NewFileInfobase("./ib").ConnectionString() {
+			t.Errorf("job %d Infobase = %v", i, got)
+		}
+
+	}
+
+	var executed []string
+	jobs[0].Run = recordRun(&executed, nil)
+
+	if err := jobs[0].Execute(context.Background()); err != nil {
+		t.Errorf("Execute() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(executed, []string{"-Server"}) {
+		t.Errorf("Execute() executed = %v", executed)
+	}
+
+}
+
+func TestParseJobs_DocExample(t *testing.T) {
+
+	// пример из документации Job
+	text := `
+command: LoadConfigFromFiles        # имя команды, см. Lookup
+name: load                          # наименование задания, необязательно
+infobase: File="./ib"               # строка соединения, см. ParseConnectionString
+user: admin                         # пользователь информационной базы, необязательно
+password: ""
+dir: ./src                          # параметры команды по тегам json
+update_db_cfg:                      # вложенная команда /UpdateDBCfg
+  dynamic: true
+`
+
+	jobs, err := ParseJobs(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ParseJobs() error = %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].Name != "load" || jobs[0].User != "admin" {
+		t.Fatalf("ParseJobs() = %+v", jobs)
+	}
+
+	want := []string{
+		"/DisableStartupDialogs",
+		"/DisableStartupMessages",
+		"/LoadConfigFromFiles ./src",
+		"/UpdateDBCfg",
+		"-Dynamic +",
+	}
+
+	if got := jobs[0].Command.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestParseJobs(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"json", `{"command": "DumpIB", "infobase": "File=./ib", "file": "./1.dt"}`, false},
+		{"unknown command", `{"command": "DumpAll", "infobase": "File=./ib"}`, true},
+		{"unknown param", `{"command": "DumpIB", "infobase": "File=./ib", "files": "./1.dt"}`, true},
+		{"no command", `{"infobase": "File=./ib"}`, true},
+		{"check failed", `{"command": "MergeCfg", "infobase": "File=./ib"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			jobs, err := ParseJobs(strings.NewReader(tt.text))
+
+			if err == nil {
+				for _, job := range jobs {
+					if err = job.Check(); err != nil {
+						break
+					}
+				}
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
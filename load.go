@@ -1,6 +1,8 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 	"strings"
 )
@@ -21,6 +23,27 @@ func (d LoadCfgOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: файл конфигурации обязателен.
+// Также проверяются параметры обновления конфигурации базы данных, если они заданы
+func (d LoadCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	if len(d.File) == 0 {
+		multierror.Append(&err, errors.Check.New("load file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	if d.UpdateDBCfg != nil {
+		multierror.Append(&err, d.UpdateDBCfg.Check())
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (d LoadCfgOptions) WithUpdateDBCfg(upd UpdateDBCfgOptions) LoadCfgOptions {
 
 	UpdateDBCfg := &upd
@@ -102,6 +125,38 @@ func (o LoadConfigFromFiles) Values() []string {
 	return v
 }
 
+// Check проверяет параметры команды: каталог загрузки обязателен,
+// -Extension и -AllExtensions, -files и -listFile взаимоисключающие.
+// Также проверяются параметры обновления конфигурации базы данных, если они заданы
+func (o LoadConfigFromFiles) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Dir) == 0 {
+		multierror.Append(&err, errors.Check.New("load dir must be set").
+			WithContext("msg", "field Dir not set"))
+	}
+
+	if len(o.Extension) > 0 && o.AllExtensions {
+		multierror.Append(&err, errors.Check.New("extension and all extensions are mutually exclusive").
+			WithContext("msg", "fields Extension and AllExtensions set together"))
+	}
+
+	if len(o.Files) > 0 && len(o.ListFile) > 0 {
+		multierror.Append(&err, errors.Check.New("files and list file are mutually exclusive").
+			WithContext("msg", "fields Files and ListFile set together"))
+	}
+
+	if o.UpdateDBCfg != nil {
+		multierror.Append(&err, o.UpdateDBCfg.Check())
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o LoadConfigFromFiles) WithExtension(extension string) LoadConfigFromFiles {
 
 	newO := o
@@ -180,3 +235,24 @@ func (o LoadExternalDataFileFromFilesOptions) Values() []string {
 	v, _ := marshaler.Marshal(o)
 	return v
 }
+
+// Check проверяет параметры команды: корневой файл выгрузки и файл внешней обработки или отчета обязательны
+func (o LoadExternalDataFileFromFilesOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Dir) == 0 {
+		multierror.Append(&err, errors.Check.New("load root file must be set").
+			WithContext("msg", "field Dir not set"))
+	}
+
+	if len(o.File) == 0 {
+		multierror.Append(&err, errors.Check.New("external data file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
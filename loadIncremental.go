@@ -0,0 +1,220 @@
+package designer
+
+import (
+	"github.com/v8platform/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NewIncrementalLoadConfigFromFiles создает команду частичной загрузки конфигурации из файлов
+// по списку измененных файлов (например, результату git diff --name-only).
+//
+// Измененные пути дополняются до полного набора файлов затронутых объектов (см. ExpandChangedFiles)
+// и записываются в файл списка listFile. Если listFile не указан, создается временный файл,
+// удаление которого остается за вызывающим.
+// Возвращаемая команда использует параметры -listFile и -updateConfigDumpInfo.
+func NewIncrementalLoadConfigFromFiles(dir, listFile string, changed ...string) (LoadConfigFromFiles, error) {
+
+	files, err := ExpandChangedFiles(dir, changed)
+
+	if err != nil {
+		return LoadConfigFromFiles{}, err
+	}
+
+	if len(files) == 0 {
+		return LoadConfigFromFiles{}, errors.Invalid.New("no configuration files to load").
+			WithContext("msg", "changed files do not belong to configuration objects")
+	}
+
+	if len(listFile) == 0 {
+
+		f, err := ioutil.TempFile("", "v8_list_file_*.txt")
+
+		if err != nil {
+			return LoadConfigFromFiles{}, errors.Internal.Wrapf(err, "create list file")
+		}
+
+		listFile = f.Name()
+		_ = f.Close()
+
+	}
+
+	if err := WriteListFile(listFile, files); err != nil {
+		return LoadConfigFromFiles{}, err
+	}
+
+	command := LoadConfigFromFiles{
+		Designer: NewDesigner(),
+		Dir:      dir,
+	}
+
+	return command.WithListFile(listFile).WithUpdateDumpInfo(), nil
+
+}
+
+// ExpandChangedFiles дополняет измененные пути до полного набора файлов затронутых объектов конфигурации,
+// выгруженной в иерархическом формате.
+//
+// Для объекта <Тип>/<Имя> загружаются файл описания <Тип>/<Имя>.xml и все файлы каталога <Тип>/<Имя>
+// (модули, формы, макеты, двоичные данные). Для корневых файлов конфигурации
+// загружаются Configuration.xml и файлы каталога Ext.
+//
+// Пути могут быть указаны относительно каталога выгрузки dir, с префиксом dir или абсолютными.
+// Пути вне каталога выгрузки и файл версий ConfigDumpInfo.xml пропускаются.
+// Если файл описания затронутого объекта отсутствует (объект удален), возвращается ошибка:
+// удаление объектов возможно только полной загрузкой.
+// Возвращаемые пути объединяются с dir.
+func ExpandChangedFiles(dir string, changed []string) ([]string, error) {
+
+	roots := make(map[string]struct{})
+
+	for _, path := range changed {
+
+		rel, ok := relativeDumpPath(dir, path)
+
+		if !ok || rel == DUMP_INFO_FILE_NAME {
+			continue
+		}
+
+		if root, ok := dumpObjectRoot(rel); ok {
+			roots[root] = struct{}{}
+		}
+
+	}
+
+	set := make(map[string]struct{})
+
+	for root := range roots {
+
+		var descr, objectDir string
+
+		if root == "" {
+			descr, objectDir = "Configuration.xml", "Ext"
+		} else {
+			descr, objectDir = root+".xml", root
+		}
+
+		if !fileExists(filepath.Join(dir, filepath.FromSlash(descr))) {
+			return nil, errors.Invalid.Newf("object description <%s> not found, full load required", descr).
+				WithContext("msg", "configuration object deleted")
+		}
+
+		set[descr] = struct{}{}
+
+		err := filepath.Walk(filepath.Join(dir, filepath.FromSlash(objectDir)), func(path string, info os.FileInfo, err error) error {
+
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			rel, _ := filepath.Rel(dir, path)
+			set[filepath.ToSlash(rel)] = struct{}{}
+			return nil
+
+		})
+
+		if err != nil {
+			return nil, errors.Internal.Wrapf(err, "expand changed files")
+		}
+
+	}
+
+	files := make([]string, 0, len(set))
+
+	for f := range set {
+		files = append(files, f)
+	}
+
+	sort.Strings(files)
+
+	for i, f := range files {
+		files[i] = filepath.Join(dir, filepath.FromSlash(f))
+	}
+
+	return files, nil
+
+}
+
+// WriteListFile записывает файл списка для параметра -listFile:
+// кодировка UTF-8, имена файлов через перенос \r\n, без пустых строк
+func WriteListFile(file string, files []string) error {
+
+	var lines []string
+
+	for _, f := range files {
+		if f = strings.TrimSpace(f); len(f) > 0 {
+			lines = append(lines, f)
+		}
+	}
+
+	if err := ioutil.WriteFile(file, []byte(strings.Join(lines, "\r\n")), 0644); err != nil {
+		return errors.Internal.Wrapf(err, "write list file <%s>", file)
+	}
+
+	return nil
+
+}
+
+// relativeDumpPath возвращает путь относительно каталога выгрузки в формате с прямыми слешами
+func relativeDumpPath(dir, path string) (string, bool) {
+
+	path = filepath.FromSlash(strings.TrimSpace(path))
+
+	if len(path) == 0 {
+		return "", false
+	}
+
+	cleanDir := filepath.Clean(dir)
+
+	if filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), cleanDir+string(filepath.Separator)) {
+
+		absDir, _ := filepath.Abs(cleanDir)
+		absPath, _ := filepath.Abs(path)
+
+		rel, err := filepath.Rel(absDir, absPath)
+
+		if err != nil {
+			return "", false
+		}
+
+		path = rel
+
+	}
+
+	path = filepath.ToSlash(filepath.Clean(path))
+
+	if path == "." || path == ".." || strings.HasPrefix(path, "../") {
+		return "", false
+	}
+
+	return path, true
+
+}
+
+// dumpObjectRoot возвращает корень объекта конфигурации <Тип>/<Имя> для пути внутри выгрузки.
+// Для корневых файлов конфигурации возвращается пустая строка.
+// Прочие файлы в корне каталога выгрузки не относятся к объектам конфигурации
+func dumpObjectRoot(rel string) (string, bool) {
+
+	parts := strings.Split(rel, "/")
+
+	switch {
+	case len(parts) == 1:
+		return "", parts[0] == "Configuration.xml"
+	case parts[0] == "Ext":
+		return "", true
+	}
+
+	return parts[0] + "/" + strings.TrimSuffix(parts[1], ".xml"), true
+
+}
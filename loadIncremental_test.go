@@ -0,0 +1,86 @@
+package designer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewIncrementalLoadConfigFromFiles(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "v8_incremental_")
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+
+	for _, f := range []string{
+		"Configuration.xml",
+		"ConfigDumpInfo.xml",
+		"Ext/ManagedApplicationModule.bsl",
+		"Catalogs/Товары.xml",
+		"Catalogs/Товары/Ext/ObjectModule.bsl",
+		"Catalogs/Товары/Forms/ФормаЭлемента.xml",
+		"Catalogs/Товары/Forms/ФормаЭлемента/Ext/Form.xml",
+		"Catalogs/Товары/Templates/Макет/Ext/Template.bin",
+		"CommonModules/ОбщегоНазначения.xml",
+		"CommonModules/ОбщегоНазначения/Ext/Module.bsl",
+	} {
+		path := filepath.Join(src, filepath.FromSlash(f))
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = ioutil.WriteFile(path, []byte("<xml/>"), 0644)
+	}
+
+	listFile := filepath.Join(dir, "list.txt")
+
+	got, err := NewIncrementalLoadConfigFromFiles(src, listFile,
+		"Catalogs/Товары/Forms/ФормаЭлемента/Ext/Form.xml",
+		filepath.Join(src, "ConfigDumpInfo.xml"),
+		"README.md",
+		"../other/file.bsl",
+	)
+
+	if err != nil {
+		t.Fatalf("NewIncrementalLoadConfigFromFiles() error = %v", err)
+	}
+
+	if got.ListFile != listFile || !got.UpdateDumpInfo || got.Dir != src {
+		t.Errorf("NewIncrementalLoadConfigFromFiles() = %v", got)
+	}
+
+	data, _ := ioutil.ReadFile(listFile)
+
+	want := filepath.Join(src, "Catalogs", "Товары.xml") + "\r\n" +
+		filepath.Join(src, "Catalogs", "Товары", "Ext", "ObjectModule.bsl") + "\r\n" +
+		filepath.Join(src, "Catalogs", "Товары", "Forms", "ФормаЭлемента.xml") + "\r\n" +
+		filepath.Join(src, "Catalogs", "Товары", "Forms", "ФормаЭлемента", "Ext", "Form.xml") + "\r\n" +
+		filepath.Join(src, "Catalogs", "Товары", "Templates", "Макет", "Ext", "Template.bin")
+
+	if string(data) != want {
+		t.Errorf("list file = %q, want %q", data, want)
+	}
+
+	files, err := ExpandChangedFiles(src, []string{"Ext/ManagedApplicationModule.bsl"})
+
+	if err != nil {
+		t.Fatalf("ExpandChangedFiles() error = %v", err)
+	}
+
+	wantFiles := []string{
+		filepath.Join(src, "Configuration.xml"),
+		filepath.Join(src, "Ext", "ManagedApplicationModule.bsl"),
+	}
+
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Errorf("ExpandChangedFiles() = %v, want %v", files, wantFiles)
+	}
+
+	if _, err := NewIncrementalLoadConfigFromFiles(src, listFile, "Documents/Удаленный.xml"); err == nil {
+		t.Errorf("NewIncrementalLoadConfigFromFiles() expected error for deleted object")
+	}
+
+	if _, err := NewIncrementalLoadConfigFromFiles(src, listFile, "README.md"); err == nil {
+		t.Errorf("NewIncrementalLoadConfigFromFiles() expected error for empty file list")
+	}
+}
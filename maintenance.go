@@ -0,0 +1,206 @@
+package designer
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/marshaler"
+	"github.com/v8platform/runner"
+	"strings"
+)
+
+const (
+	MAINTENANCE_ACTION_LOCK   = "LockSessions"
+	MAINTENANCE_ACTION_UNLOCK = "UnlockSessions"
+)
+
+var _ command = (*ExecuteOptions)(nil)
+
+///Execute <имя файла внешней обработки> [/C <строка текста>]
+//— запуск внешней обработки в режиме 1С:Предприятие сразу после старта системы.
+//
+///C <строка текста> — передача параметра в прикладное решение
+//(доступен через свойство глобального контекста ПараметрЗапуска).
+//
+//Код доступа /UC передается опцией запуска runner.WithUC.
+type ExecuteOptions struct {
+	DisableStartupDialogs  bool `v8:"/DisableStartupDialogs" json:"disable_startup_dialogs"`
+	DisableStartupMessages bool `v8:"/DisableStartupMessages" json:"disable_startup_messages"`
+
+	File string `v8:"/Execute" json:"file"`
+
+	Param string `v8:"/C, optional" json:"param"`
+}
+
+func (o ExecuteOptions) Command() string {
+	return COMMAND_ENTERPRISE
+}
+
+func (o ExecuteOptions) Check() error {
+
+	var err multierror.Error
+
+	if len(o.File) == 0 {
+		multierror.Append(&err, errors.Check.New("data processor file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+}
+
+func (o ExecuteOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+func (o ExecuteOptions) WithParam(param string) ExecuteOptions {
+
+	newO := o
+	newO.Param = param
+	return newO
+
+}
+
+func NewExecute(file string) ExecuteOptions {
+
+	return ExecuteOptions{
+		DisableStartupDialogs:  true,
+		DisableStartupMessages: true,
+		File:                   file,
+	}
+
+}
+
+// MaintenanceWindow регламентное окно: блокировка сеансов информационной базы
+// на время обновления с помощью внешней обработки.
+//
+// Обработка запускается в режиме 1С:Предприятие и получает параметр запуска (/C) вида
+//
+//	<Действие>;AccessCode=<код доступа>;Message=<сообщение>;BlockScheduledJobs=<true|false>
+//
+// где действие — LockSessions (установить блокировку и завершить сеансы)
+// или UnlockSessions (снять блокировку).
+// Сообщение и код доступа не должны содержать ';', см. Check.
+//
+// Команды блокировки запускаются с кодом доступа (/UC), см. Options,
+// команды конфигуратора — с кодом доступа Designer.AccessCode.
+//
+// Пример: снятие блокировки выполняется и при ошибке обновления — как откат шага lock
+//
+//	w := NewMaintenanceWindow("./tools/SessionLock.epf", "123")
+//	update := UpdateDBCfgOptions{Designer: NewDesigner(), Server: true}
+//	update.AccessCode = w.AccessCode
+//	p := NewPipeline(ib).Add(
+//		NewStep("lock", w.Lock()).WithOptions(w.Options()...).WithRollback(w.Unlock()),
+//		NewStep("update", update),
+//		NewStep("unlock", w.Unlock()).WithOptions(w.Options()...),
+//	)
+//	report, err := p.Execute(ctx)
+type MaintenanceWindow struct {
+	// Processor внешняя обработка управления блокировкой сеансов (*.epf)
+	Processor string `json:"processor"`
+
+	// AccessCode код разрешения запуска (/UC), устанавливаемый блокировкой
+	AccessCode string `json:"access_code"`
+
+	// Message сообщение пользователям о блокировке
+	Message string `json:"message"`
+
+	// BlockScheduledJobs блокировать выполнение регламентных заданий
+	BlockScheduledJobs bool `json:"block_scheduled_jobs"`
+}
+
+// NewMaintenanceWindow создает регламентное окно с блокировкой регламентных заданий
+func NewMaintenanceWindow(processor, accessCode string) MaintenanceWindow {
+
+	return MaintenanceWindow{
+		Processor:          processor,
+		AccessCode:         accessCode,
+		BlockScheduledJobs: true,
+	}
+
+}
+
+func (w MaintenanceWindow) WithMessage(message string) MaintenanceWindow {
+
+	newW := w
+	newW.Message = message
+	return newW
+
+}
+
+// Check проверяет параметры окна: обработка должна быть указана,
+// сообщение и код доступа не должны содержать разделитель параметров ';'
+func (w MaintenanceWindow) Check() error {
+
+	var err multierror.Error
+
+	if len(w.Processor) == 0 {
+		multierror.Append(&err, errors.Check.New("data processor file must be set").
+			WithContext("msg", "field Processor not set"))
+	}
+
+	if strings.Contains(w.Message, ";") {
+		multierror.Append(&err, errors.Check.New("message must not contain ';'").
+			WithContext("msg", "field Message contains parameter separator"))
+	}
+
+	if strings.Contains(w.AccessCode, ";") {
+		multierror.Append(&err, errors.Check.New("access code must not contain ';'").
+			WithContext("msg", "field AccessCode contains parameter separator"))
+	}
+
+	return err.ErrorOrNil()
+}
+
+// Options возвращает опции запуска команд окна: код доступа /UC (runner.WithUC)
+func (w MaintenanceWindow) Options() []interface{} {
+	return []interface{}{runner.WithUC(w.AccessCode)}
+}
+
+// Lock возвращает команду установки блокировки сеансов
+func (w MaintenanceWindow) Lock() ExecuteOptions {
+	return w.execute(MAINTENANCE_ACTION_LOCK)
+}
+
+// Unlock возвращает команду снятия блокировки сеансов.
+// Команда запускается с кодом доступа (см. Options), т.к. соединения с информационной базой заблокированы
+func (w MaintenanceWindow) Unlock() ExecuteOptions {
+	return w.execute(MAINTENANCE_ACTION_UNLOCK)
+}
+
+// Commands возвращает команды, выполняемые в регламентном окне: блокировка,
+// переданные команды и снятие блокировки.
+// Все команды запускаются с опциями Options
+func (w MaintenanceWindow) Commands(what ...runner.Command) []runner.Command {
+
+	commands := []runner.Command{w.Lock()}
+	commands = append(commands, what...)
+	return append(commands, w.Unlock())
+
+}
+
+func (w MaintenanceWindow) execute(action string) ExecuteOptions {
+
+	return NewExecute(w.Processor).
+		WithParam(w.param(action))
+
+}
+
+func (w MaintenanceWindow) param(action string) string {
+
+	blockJobs := "false"
+	if w.BlockScheduledJobs {
+		blockJobs = "true"
+	}
+
+	params := []string{
+		action,
+		"AccessCode=" + w.AccessCode,
+		"Message=" + w.Message,
+		"BlockScheduledJobs=" + blockJobs,
+	}
+
+	return strings.Join(params, ";")
+}
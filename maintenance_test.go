@@ -0,0 +1,125 @@
+package designer
+
+import (
+	"context"
+	"github.com/v8platform/designer/tests"
+	"github.com/v8platform/runner"
+	"reflect"
+	"testing"
+)
+
+func TestMaintenanceWindow_Commands(t *testing.T) {
+
+	w := NewMaintenanceWindow("./SessionLock.epf", "123").
+		WithMessage("Обновление, ждите")
+
+	update := UpdateDBCfgOptions{Designer: NewDesigner(), Server: true}
+	update.AccessCode = w.AccessCode
+
+	commands := w.Commands(update)
+
+	want := [][]string{
+		{
+			"/DisableStartupDialogs",
+			"/DisableStartupMessages",
+			"/Execute ./SessionLock.epf",
+			"/C LockSessions;AccessCode=123;Message=Обновление, ждите;BlockScheduledJobs=true",
+		},
+		{
+			"/DisableStartupDialogs",
+			"/DisableStartupMessages",
+			"/UpdateDBCfg",
+			"-Server",
+		},
+		{
+			"/DisableStartupDialogs",
+			"/DisableStartupMessages",
+			"/Execute ./SessionLock.epf",
+			"/C UnlockSessions;AccessCode=123;Message=Обновление, ждите;BlockScheduledJobs=true",
+		},
+	}
+
+	if len(commands) != len(want) {
+		t.Fatalf("Commands() len = %v, want %v", len(commands), len(want))
+	}
+
+	for i, c := range commands {
+		if got := c.Values(); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Commands()[%d].Values() = %v, want %v", i, got, want[i])
+		}
+	}
+
+	if got := commands[0].Command(); got != COMMAND_ENTERPRISE {
+		t.Errorf("Command() = %v, want %v", got, COMMAND_ENTERPRISE)
+	}
+
+	if err := (ExecuteOptions{}).Check(); err == nil {
+		t.Errorf("Check() error = nil, want error for empty File")
+	}
+
+	args := runner.NewPlatformRunner(tests.NewFileIB("./ib"), update, update.RunOptions()...).Args()
+
+	if !containsFold(args, "/UC 123") {
+		t.Errorf("Args() = %v, want /UC 123", args)
+	}
+
+	args = runner.NewPlatformRunner(tests.NewFileIB("./ib"), commands[0], w.Options()...).Args()
+
+	if !containsFold(args, "/UC 123") {
+		t.Errorf("Args() = %v, want /UC 123", args)
+	}
+
+}
+
+func TestMaintenanceWindow_UnlockOnError(t *testing.T) {
+
+	w := NewMaintenanceWindow("./SessionLock.epf", "123")
+
+	update := UpdateDBCfgOptions{Designer: NewDesigner(), Server: true}
+	update.AccessCode = w.AccessCode
+
+	var executed []string
+
+	p := NewPipeline(NewFileInfobase("./ib")).Add(
+		NewStep("lock", w.Lock()).WithOptions(w.Options()...).WithRollback(w.Unlock()),
+		NewStep("update", update),
+		NewStep("unlock", w.Unlock()).WithOptions(w.Options()...),
+	)
+	p.Run = recordRun(&executed, map[string]string{"-Server": "Ошибка обновления"})
+
+	if _, err := p.Execute(context.Background()); err == nil {
+		t.Fatalf("Execute() error = nil, want update error")
+	}
+
+	want := []string{
+		"/C " + w.param(MAINTENANCE_ACTION_LOCK),
+		"-Server",
+		"/C " + w.param(MAINTENANCE_ACTION_UNLOCK),
+	}
+
+	if !reflect.DeepEqual(executed, want) {
+		t.Errorf("Execute() executed = %v, want %v", executed, want)
+	}
+}
+
+func TestMaintenanceWindow_Check(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		w       MaintenanceWindow
+		wantErr bool
+	}{
+		{"valid", NewMaintenanceWindow("./SessionLock.epf", "123").WithMessage("Обновление, ждите"), false},
+		{"no processor", NewMaintenanceWindow("", "123"), true},
+		{"message separator", NewMaintenanceWindow("./SessionLock.epf", "123").WithMessage("Обновление; ждите"), true},
+		{"access code separator", NewMaintenanceWindow("./SessionLock.epf", "1;2"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.w.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,66 @@
+package designer
+
+import (
+	"strings"
+)
+
+// PASSWORD_MASK значение, которым заменяются пароли и коды доступа при выводе параметров запуска
+const PASSWORD_MASK = "******"
+
+var (
+	// параметры запуска, значение которых скрывается (сравниваются без учета регистра)
+	maskedArgs = []string{"/P ", "/ConfigurationRepositoryP ", "-Pwd ", "/UC "}
+
+	// параметры вида Ключ=значение, разделенные ';' (строка соединения, параметр /C регламентного окна),
+	// значение которых скрывается (сравниваются без учета регистра)
+	maskedParams = []string{"Pwd=", "DBPwd=", "SPwd=", "AccessCode="}
+)
+
+// MaskValues возвращает копию параметров запуска, в которой пароли (/P, /ConfigurationRepositoryP, -pwd),
+// коды доступа (/UC, AccessCode) и пароли строки соединения (Pwd, DBPwd, SPwd) заменены на PASSWORD_MASK
+func MaskValues(values []string) []string {
+
+	if values == nil {
+		return nil
+	}
+
+	masked := make([]string, len(values))
+
+	for i, value := range values {
+		masked[i] = maskValue(value)
+	}
+
+	return masked
+}
+
+// MaskConnectionString заменяет пароли строки соединения (Pwd, DBPwd, SPwd) и код доступа AccessCode на PASSWORD_MASK
+func MaskConnectionString(connect string) string {
+
+	parts := strings.Split(connect, ";")
+
+	for i, part := range parts {
+
+		trimmed := strings.TrimSpace(part)
+
+		for _, key := range maskedParams {
+			if hasPrefixFold(trimmed, key) {
+				parts[i] = trimmed[:len(key)] + PASSWORD_MASK
+				break
+			}
+		}
+
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func maskValue(value string) string {
+
+	for _, key := range maskedArgs {
+		if hasPrefixFold(value, key) {
+			return value[:len(key)] + PASSWORD_MASK
+		}
+	}
+
+	return MaskConnectionString(value)
+}
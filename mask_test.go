@@ -0,0 +1,41 @@
+package designer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaskValues(t *testing.T) {
+
+	values := []string{
+		"/N admin",
+		"/P secret",
+		"/ConfigurationRepositoryP secret",
+		"-pwd secret",
+		"/UC 123",
+		"/IBConnectionString Srvr=srv;Ref=ib;Usr=admin;Pwd=secret;",
+		"DBPwd=secret",
+		"/C LockSessions;AccessCode=123;Message=Обновление",
+		"/LoadCfg ./1cv8.cf",
+	}
+
+	want := []string{
+		"/N admin",
+		"/P " + PASSWORD_MASK,
+		"/ConfigurationRepositoryP " + PASSWORD_MASK,
+		"-pwd " + PASSWORD_MASK,
+		"/UC " + PASSWORD_MASK,
+		"/IBConnectionString Srvr=srv;Ref=ib;Usr=admin;Pwd=" + PASSWORD_MASK + ";",
+		"DBPwd=" + PASSWORD_MASK,
+		"/C LockSessions;AccessCode=" + PASSWORD_MASK + ";Message=Обновление",
+		"/LoadCfg ./1cv8.cf",
+	}
+
+	if got := MaskValues(values); !reflect.DeepEqual(got, want) {
+		t.Errorf("MaskValues() = %v, want %v", got, want)
+	}
+
+	if values[1] != "/P secret" {
+		t.Errorf("MaskValues() changed source values: %v", values)
+	}
+}
@@ -0,0 +1,298 @@
+package designer
+
+import (
+	"encoding/xml"
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/marshaler"
+	"io"
+	"os"
+)
+
+///MergeCfg <имя cf-файла> -Settings <имя файла настроек> [-EnableSupport | -DisableSupport]
+//[-IncludeObjectsByUnresolvedRefs | -ClearUnresolvedRefs] [-force]
+//— объединение текущей конфигурации с файлом (с использованием файла настроек).
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs83\ИБ83" /MergeCfg "D:\Vendor\1Cv8.cf" -Settings "D:\Vendor\MergeSettings.xml" -EnableSupport -force
+type MergeCfgOptions struct {
+	Designer `v8:",inherit" json:"designer"`
+
+	//<имя cf-файла> — имя cf-файла с объединяемой конфигурацией.
+	File string `v8:"/MergeCfg" json:"file"`
+
+	//-Settings <имя файла настроек> — содержит имя файла настроек объединения.
+	Settings string `v8:"-Settings" json:"settings"`
+
+	//-EnableSupport — если есть возможность, то конфигурация будет поставлена на поддержку.
+	EnableSupport bool `v8:"-EnableSupport, optional" json:"enable_support"`
+
+	//-DisableSupport — конфигурация не будет поставлена на поддержку, даже если есть такая возможность.
+	DisableSupport bool `v8:"-DisableSupport, optional" json:"disable_support"`
+
+	//-IncludeObjectsByUnresolvedRefs — если в настройках есть объекты, не включенные в список объединяемых
+	//и отсутствующие в основной конфигурации, на которые есть ссылки из объектов, включенных в список,
+	//то такие объекты также помечаются для объединения, и выполняется попытка продолжить объединение.
+	IncludeObjectsByUnresolvedRefs bool `v8:"-IncludeObjectsByUnresolvedRefs, optional" json:"include_objects_by_unresolved_refs"`
+
+	//-ClearUnresolvedRefs — очищение ссылок на объекты, не включенные в список объединяемых.
+	ClearUnresolvedRefs bool `v8:"-ClearUnresolvedRefs, optional" json:"clear_unresolved_refs"`
+
+	//-force — объединение будет выполнено несмотря на наличие предупреждений:
+	//о применении настроек,
+	//о дважды измененных свойствах, для которых не был выбран режим объединения,
+	//об удаляемых объектах, на которые найдены ссылки в объектах, не участвующие в объединении.
+	Force bool `v8:"-force, optional" json:"force"`
+}
+
+func (o MergeCfgOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(o)
+	return v
+
+}
+
+// Check проверяет параметры команды: файл конфигурации и файл настроек обязательны,
+// -EnableSupport и -DisableSupport, -IncludeObjectsByUnresolvedRefs и -ClearUnresolvedRefs взаимоисключающие
+func (o MergeCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.File) == 0 {
+		multierror.Append(&err, errors.Check.New("merge file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	if len(o.Settings) == 0 {
+		multierror.Append(&err, errors.Check.New("merge settings must be set").
+			WithContext("msg", "field Settings not set"))
+	}
+
+	if o.EnableSupport && o.DisableSupport {
+		multierror.Append(&err, errors.Check.New("enable support and disable support are mutually exclusive").
+			WithContext("msg", "fields EnableSupport and DisableSupport set together"))
+	}
+
+	if o.IncludeObjectsByUnresolvedRefs && o.ClearUnresolvedRefs {
+		multierror.Append(&err, errors.Check.New("include objects and clear unresolved refs are mutually exclusive").
+			WithContext("msg", "fields IncludeObjectsByUnresolvedRefs and ClearUnresolvedRefs set together"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o MergeCfgOptions) WithSettings(file string) MergeCfgOptions {
+
+	newO := o
+	newO.Settings = file
+	return newO
+
+}
+
+func (o MergeCfgOptions) WithSupport(enable bool) MergeCfgOptions {
+
+	newO := o
+	newO.EnableSupport = enable
+	newO.DisableSupport = !enable
+	return newO
+
+}
+
+func (o MergeCfgOptions) WithIncludeObjectsByUnresolvedRefs() MergeCfgOptions {
+
+	newO := o
+	newO.IncludeObjectsByUnresolvedRefs = true
+	newO.ClearUnresolvedRefs = false
+	return newO
+
+}
+
+func (o MergeCfgOptions) WithClearUnresolvedRefs() MergeCfgOptions {
+
+	newO := o
+	newO.ClearUnresolvedRefs = true
+	newO.IncludeObjectsByUnresolvedRefs = false
+	return newO
+
+}
+
+// NewMergeCfg создает команду объединения конфигурации с файлом по файлу настроек
+func NewMergeCfg(file, settings string) MergeCfgOptions {
+
+	return MergeCfgOptions{
+		Designer: NewDesigner(),
+		File:     file,
+		Settings: settings,
+	}
+
+}
+
+type MergeRuleType string
+type ConfigurationsRelationType string
+
+const (
+	MERGE_RULE_GET_FROM_SECOND              MergeRuleType              = "GetFromSecondConfiguration"
+	MERGE_RULE_PRIORITIZING_FIRST           MergeRuleType              = "MergePrioritizingFirstConfiguration"
+	MERGE_RULE_PRIORITIZING_SECOND          MergeRuleType              = "MergePrioritizingSecondConfiguration"
+	MERGE_RULE_DO_NOT_MERGE                 MergeRuleType              = "DoNotMerge"
+	MERGE_CONFIGURATIONS_NOT_RELATED        ConfigurationsRelationType = "ConfigurationsNotRelated"
+	MERGE_SECOND_IS_DESCENDANT_OF_FIRST     ConfigurationsRelationType = "SecondConfigurationIsDescendantOfFirstConfiguration"
+	MERGE_SETTINGS_NAMESPACE                                           = "http://v8.1c.ru/8.3/config/merge/settings"
+	MERGE_SETTINGS_VERSION                                             = "1.1"
+	MERGE_SETTINGS_DEFAULT_PLATFORM_VERSION                            = "8.3.10"
+)
+
+// MergeSettings файл настроек объединения конфигураций,
+// используемый командами /MergeCfg и /UpdateCfg (параметр -Settings)
+type MergeSettings struct {
+	XMLName         xml.Name `xml:"http://v8.1c.ru/8.3/config/merge/settings Settings" json:"-"`
+	Version         string   `xml:"version,attr" json:"version"`
+	PlatformVersion string   `xml:"platformVersion,attr" json:"platform_version"`
+
+	Parameters MergeParameters   `xml:"Parameters" json:"parameters"`
+	Conformity []MergeConformity `xml:"Conformities>Conformity,omitempty" json:"conformity"`
+	Objects    []MergeObject     `xml:"Objects>Object" json:"objects"`
+}
+
+// MergeParameters общие параметры объединения
+type MergeParameters struct {
+	// ConfigurationsRelation отношение объединяемых конфигураций
+	ConfigurationsRelation ConfigurationsRelationType `xml:"ConfigurationsRelation,omitempty" json:"configurations_relation"`
+
+	// AllowMainConfigurationObjectDeletion разрешить удаление объектов основной конфигурации
+	AllowMainConfigurationObjectDeletion bool `xml:"AllowMainConfigurationObjectDeletion" json:"allow_main_configuration_object_deletion"`
+
+	// SupportRules правила поддержки для объектов поставщика при постановке на поддержку
+	SupportRules *MergeSupportRules `xml:"SupportRules,omitempty" json:"support_rules,omitempty"`
+}
+
+// MergeSupportRules правила поддержки объектов поставщика
+type MergeSupportRules struct {
+	// ChangesAllowedRule правило для объектов, изменения которых разрешены поставщиком
+	ChangesAllowedRule RepositorySupportEditObjectsType `xml:"ChangesAllowedRule" json:"changes_allowed_rule"`
+
+	// ChangesNotRecommendedRule правило для объектов, изменения которых не рекомендуются поставщиком
+	ChangesNotRecommendedRule RepositorySupportEditObjectsType `xml:"ChangesNotRecommendedRule" json:"changes_not_recommended_rule"`
+}
+
+// MergeConformity сопоставление объекта основной конфигурации объекту объединяемой конфигурации
+type MergeConformity struct {
+	MainObject   string `xml:"MainObject" json:"main_object"`
+	SecondObject string `xml:"SecondObject" json:"second_object"`
+}
+
+// MergeObject объект, участвующий в объединении
+type MergeObject struct {
+	// FullName полное имя объекта в объединяемой конфигурации, например Catalog.Номенклатура
+	FullName string `xml:"fullNameInSecondConfiguration,attr" json:"full_name"`
+
+	// MergeRule режим объединения объекта
+	MergeRule MergeRuleType `xml:"MergeRule" json:"merge_rule"`
+}
+
+// NewMergeSettings создает настройки объединения для конфигурации-наследника
+func NewMergeSettings() *MergeSettings {
+
+	return &MergeSettings{
+		Version:         MERGE_SETTINGS_VERSION,
+		PlatformVersion: MERGE_SETTINGS_DEFAULT_PLATFORM_VERSION,
+		Parameters: MergeParameters{
+			ConfigurationsRelation: MERGE_SECOND_IS_DESCENDANT_OF_FIRST,
+		},
+	}
+
+}
+
+// AddObject добавляет объект в список объединяемых или изменяет режим объединения уже добавленного объекта
+func (s *MergeSettings) AddObject(fullName string, rule MergeRuleType) *MergeSettings {
+
+	for i := range s.Objects {
+		if s.Objects[i].FullName == fullName {
+			s.Objects[i].MergeRule = rule
+			return s
+		}
+	}
+
+	s.Objects = append(s.Objects, MergeObject{FullName: fullName, MergeRule: rule})
+	return s
+
+}
+
+// WithSupportRules устанавливает правила поддержки для объектов поставщика
+func (s *MergeSettings) WithSupportRules(changesAllowed, changesNotRecommended RepositorySupportEditObjectsType) *MergeSettings {
+
+	s.Parameters.SupportRules = &MergeSupportRules{
+		ChangesAllowedRule:        changesAllowed,
+		ChangesNotRecommendedRule: changesNotRecommended,
+	}
+	return s
+
+}
+
+// Write выводит файл настроек объединения в формате XML
+func (s MergeSettings) Write(w io.Writer) error {
+
+	if len(s.Version) == 0 {
+		s.Version = MERGE_SETTINGS_VERSION
+	}
+
+	if len(s.PlatformVersion) == 0 {
+		s.PlatformVersion = MERGE_SETTINGS_DEFAULT_PLATFORM_VERSION
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+
+}
+
+// WriteFile записывает файл настроек объединения в кодировке UTF-8
+func (s MergeSettings) WriteFile(file string) error {
+
+	f, err := os.Create(file)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+
+}
+
+// ReadMergeSettings читает файл настроек объединения
+func ReadMergeSettings(file string) (*MergeSettings, error) {
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := &MergeSettings{}
+
+	if err := xml.NewDecoder(f).Decode(s); err != nil {
+		return nil, errors.Invalid.Wrapf(err, "read merge settings <%s>", file)
+	}
+
+	return s, nil
+
+}
@@ -0,0 +1,113 @@
+package designer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeCfgOptions_Values(t *testing.T) {
+
+	tests := []struct {
+		name string
+		cmd  MergeCfgOptions
+		want []string
+	}{
+		{
+			"simple",
+			MergeCfgOptions{File: "./1Cv8.cf", Settings: "./settings.xml"},
+			[]string{
+				"/MergeCfg ./1Cv8.cf",
+				"-Settings ./settings.xml",
+			},
+		},
+		{
+			"support and refs",
+			MergeCfgOptions{File: "./1Cv8.cf", Force: true}.
+				WithSettings("./settings.xml").
+				WithSupport(true).
+				WithClearUnresolvedRefs(),
+			[]string{
+				"/MergeCfg ./1Cv8.cf",
+				"-Settings ./settings.xml",
+				"-EnableSupport",
+				"-ClearUnresolvedRefs",
+				"-force",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeCfgOptions_Check(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cmd     MergeCfgOptions
+		wantErr bool
+	}{
+		{"simple", NewMergeCfg("./1Cv8.cf", "./settings.xml"), false},
+		{"no settings", NewMergeCfg("./1Cv8.cf", ""), true},
+		{"support", MergeCfgOptions{File: "./1Cv8.cf", Settings: "./settings.xml", EnableSupport: true, DisableSupport: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeSettings_WriteFile(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "v8_merge_")
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "settings.xml")
+
+	settings := NewMergeSettings().
+		AddObject("Catalog.Номенклатура", MERGE_RULE_GET_FROM_SECOND).
+		AddObject("Document.Реализация", MERGE_RULE_PRIORITIZING_FIRST).
+		AddObject("Catalog.Номенклатура", MERGE_RULE_PRIORITIZING_SECOND).
+		WithSupportRules(REPOSITORY_SUPPORT_IS_EDITABLE, REPOSITORY_SUPPORT_NOT_EDITABLE)
+
+	if err := settings.WriteFile(file); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, _ := ioutil.ReadFile(file)
+	text := string(data)
+
+	for _, want := range []string{
+		`<Settings xmlns="http://v8.1c.ru/8.3/config/merge/settings" version="1.1" platformVersion="8.3.10">`,
+		`<Object fullNameInSecondConfiguration="Catalog.Номенклатура">`,
+		`<MergeRule>MergePrioritizingSecondConfiguration</MergeRule>`,
+		`<ChangesAllowedRule>ObjectIsEditableSupportEnabled</ChangesAllowedRule>`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("WriteFile() output does not contain %s:\n%s", want, text)
+		}
+	}
+
+	got, err := ReadMergeSettings(file)
+
+	if err != nil {
+		t.Fatalf("ReadMergeSettings() error = %v", err)
+	}
+
+	got.XMLName = settings.XMLName
+
+	if !reflect.DeepEqual(got, settings) {
+		t.Errorf("ReadMergeSettings() = %v, want %v", got, settings)
+	}
+}
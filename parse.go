@@ -0,0 +1,543 @@
+package designer
+
+import (
+	"github.com/v8platform/errors"
+	"github.com/v8platform/marshaler"
+	"github.com/v8platform/runner"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParsedArgs разобранные параметры запуска 1cv8
+type ParsedArgs struct {
+	// Mode режим запуска: DESIGNER или ENTERPRISE
+	Mode string
+
+	// Infobase информационная база из параметров /F, /S или /IBConnectionString
+	Infobase runner.Infobase
+
+	// User и Password параметры /N и /P
+	User     string
+	Password string
+
+	// UnlockCode код доступа /UC, передается в runner опцией runner.WithUC.
+	// Для команд с общими параметрами Designer также заполняется Designer.AccessCode
+	UnlockCode string
+
+	Command runner.Command
+}
+
+var (
+	// параметры запуска, которые не относятся к командам и пропускаются при разборе
+	parseIgnoredArgs = []string{
+		"/WA-", "/WA+", "/DisableSplash", "/AppAutoCheckVersion-", "/AppAutoCheckVersion+",
+		"/AppAutoCheckMode", "/UseHwLicenses-", "/UseHwLicenses+",
+	}
+
+	// исполняемые файлы платформы, которые могут быть указаны первым параметром
+	parseExecutables = []string{"1cv8", "1cv8c", "1cv8s"}
+)
+
+// Parse разбирает параметры запуска конфигуратора (например, из командного файла)
+// и возвращает команду пакета, заполненную по тегам v8.
+//
+// Параметры могут быть переданы по одному (/LoadCfg, ./1cv8.cf) или вместе со значением,
+// как их возвращает Values() (/LoadCfg ./1cv8.cf). Параметры соединения и аутентификации
+// (/F, /S, /IBConnectionString, /N, /P, /UC) пропускаются, см. ParseArgs.
+// Повторяющиеся параметры (-f, -v, -comment) собираются в список или многострочное значение.
+// Неизвестный параметр считается ошибкой.
+//
+// Пример:
+//
+//	what, err := Parse([]string{"DESIGNER", "/F", "./ib", "/LoadCfg", "./1cv8.cf", "/UpdateDBCfg", "-Dynamic-"})
+func Parse(args []string) (runner.Command, error) {
+
+	parsed, err := ParseArgs(args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.Command, nil
+}
+
+// ParseArgs разбирает параметры запуска, включая информационную базу и пользователя
+func ParseArgs(args []string) (ParsedArgs, error) {
+
+	tokens := splitArgs(args)
+
+	parsed := ParsedArgs{Mode: COMMAND_DESIGNER}
+
+	if len(tokens) > 0 && isExecutable(tokens[0]) {
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) > 0 {
+		switch strings.ToUpper(tokens[0]) {
+		case COMMAND_DESIGNER, COMMAND_ENTERPRISE:
+			parsed.Mode = strings.ToUpper(tokens[0])
+			tokens = tokens[1:]
+		case COMMAND_CREATEINFOBASE:
+			return ParsedArgs{}, errors.Invalid.New("parse CREATEINFOBASE arguments not supported")
+		}
+	}
+
+	candidates := parseCandidates(parsed.Mode, tokens)
+
+	if len(candidates) == 0 {
+		return ParsedArgs{}, errors.Invalid.New("command not found in arguments")
+	}
+
+	var err error
+
+	for _, t := range candidates {
+
+		var result ParsedArgs
+
+		if result, err = parseCommand(t, tokens); err == nil {
+			result.Mode = parsed.Mode
+			return result, nil
+		}
+
+	}
+
+	return ParsedArgs{}, err
+}
+
+// parseCandidates возвращает типы команд режима mode, ключ которых встречается первым среди параметров.
+// Если ключ используется несколькими командами (например, /DumpConfigToFiles),
+// возвращаются все такие команды в порядке имен
+func parseCandidates(mode string, tokens []string) []reflect.Type {
+
+	keys := make(map[string][]reflect.Type)
+
+	for _, name := range Commands() {
+
+		what, _ := NewCommand(name)
+
+		if what.Command() != mode {
+			continue
+		}
+
+		t := reflect.TypeOf(what).Elem()
+
+		if key := commandKey(t); len(key) > 0 {
+			keys[strings.ToLower(key)] = append(keys[strings.ToLower(key)], t)
+		}
+
+	}
+
+	for _, token := range tokens {
+		if types, ok := keys[strings.ToLower(token)]; ok {
+			sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+			return types
+		}
+	}
+
+	return nil
+}
+
+// commandKey возвращает ключ команды: тег поля command или первый
+// параметр вида /Команда собственных (не наследуемых) полей структуры
+func commandKey(t reflect.Type) string {
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		info := marshaler.GetFieldTagInfo(field)
+
+		if info == nil || info.Inherit {
+			continue
+		}
+
+		if field.Name == marshaler.CommandFieldName ||
+			strings.HasPrefix(info.Name, "/") && field.Type.Kind() != reflect.Bool {
+			return info.Name
+		}
+
+	}
+
+	return ""
+}
+
+// argField параметр команды, соответствующий полю структуры
+type argField struct {
+	info  *marshaler.FieldTagInfo
+	path  []int
+	typ   reflect.Type
+	scope int
+
+	// command поле с ключом команды (command struct{})
+	command bool
+}
+
+func collectArgFields(t reflect.Type, path []int, scope int, nextScope *int, fields *[]argField) {
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		info := marshaler.GetFieldTagInfo(field)
+
+		if info == nil {
+			continue
+		}
+
+		fieldPath := append(append([]int{}, path...), i)
+
+		if info.Inherit {
+
+			switch {
+			case field.Type.Kind() == reflect.Struct:
+				collectArgFields(field.Type, fieldPath, scope, nextScope, fields)
+			case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct:
+				*nextScope++
+				collectArgFields(field.Type.Elem(), fieldPath, *nextScope, nextScope, fields)
+			}
+
+			continue
+		}
+
+		*fields = append(*fields, argField{
+			info:    info,
+			path:    fieldPath,
+			typ:     field.Type,
+			scope:   scope,
+			command: field.Name == marshaler.CommandFieldName,
+		})
+
+	}
+
+}
+
+func parseCommand(t reflect.Type, tokens []string) (ParsedArgs, error) {
+
+	var fields []argField
+	nextScope := 0
+	collectArgFields(t, nil, 0, &nextScope, &fields)
+
+	root := reflect.New(t)
+	parsed := ParsedArgs{}
+	scope := 0
+
+	for i := 0; i < len(tokens); i++ {
+
+		token := tokens[i]
+
+		next := func() (string, error) {
+			if i+1 >= len(tokens) {
+				return "", errors.Invalid.Newf("parameter <%s> requires value", token)
+			}
+			i++
+			return unquoteArg(tokens[i]), nil
+		}
+
+		field, inline, hasInline := matchArgField(fields, token, scope)
+
+		if field == nil {
+
+			switch {
+			case containsFold(parseIgnoredArgs, token):
+				continue
+			case strings.EqualFold(token, "/Out"), strings.EqualFold(token, "/DumpResult"):
+				value, err := next()
+				if err != nil {
+					return ParsedArgs{}, err
+				}
+				setResultFile(root, token, value)
+				continue
+			case strings.EqualFold(token, "-NoTruncate"):
+				setResultFile(root, token, "")
+				continue
+			case strings.EqualFold(token, "/N"), strings.EqualFold(token, "/P"),
+				strings.EqualFold(token, "/UC"), strings.EqualFold(token, "/F"), strings.EqualFold(token, "/S"),
+				strings.EqualFold(token, "/IBConnectionString"):
+			case hasPrefixFold(token, "/F"), hasPrefixFold(token, "/S"):
+				ib, err := ParseConnectionString(token)
+				if err != nil {
+					return ParsedArgs{}, err
+				}
+				parsed.Infobase = ib
+				continue
+			case strings.HasPrefix(token, "/") || strings.HasPrefix(token, "-"):
+				return ParsedArgs{}, errors.Invalid.Newf("unknown parameter <%s>", token)
+			default:
+				return ParsedArgs{}, errors.Invalid.Newf("unexpected argument <%s>", token)
+			}
+
+			value, err := next()
+
+			if err != nil {
+				return ParsedArgs{}, err
+			}
+
+			switch strings.ToUpper(token) {
+			case "/N":
+				parsed.User = value
+			case "/P":
+				parsed.Password = value
+			case "/UC":
+				parsed.UnlockCode = value
+				setResultFile(root, token, value)
+			default:
+				ib, err := ParseConnectionString(token + " " + quoteConnectionArg(token, value))
+				if err != nil {
+					return ParsedArgs{}, err
+				}
+				parsed.Infobase = ib
+			}
+
+			continue
+		}
+
+		v := argFieldValue(root, field.path)
+		scope = field.scope
+
+		switch {
+		case field.command:
+			continue
+		case field.typ.Kind() == reflect.Bool:
+
+			value := true
+			format := inline
+
+			if !hasInline && i+1 < len(tokens) && isBoolFormat(field.info, tokens[i+1]) {
+				i++
+				format = tokens[i]
+			}
+
+			if len(format) > 0 && len(field.info.FalseFormat) > 0 && format == field.info.FalseFormat {
+				value = false
+			}
+
+			v.SetBool(value)
+			continue
+
+		}
+
+		value := inline
+
+		if !hasInline {
+
+			var err error
+
+			if value, err = next(); err != nil {
+				return ParsedArgs{}, err
+			}
+
+		}
+
+		for _, item := range splitRepeatedValue(v, field.info, value) {
+			if err := setArgValue(v, unquoteArg(item)); err != nil {
+				return ParsedArgs{}, errors.Invalid.Wrapf(err, "parameter <%s>", token)
+			}
+		}
+
+	}
+
+	parsed.Command = root.Interface().(runner.Command)
+
+	return parsed, nil
+}
+
+// matchArgField возвращает поле для параметра token.
+// Для параметров вида /Параметр предпочитаются поля основной команды,
+// для параметров вида -параметр — поля текущей (вложенной) команды.
+// Значение может быть указано слитно: -Dynamic+ или Key=value
+func matchArgField(fields []argField, token string, scope int) (*argField, string, bool) {
+
+	var found *argField
+	var inline string
+	var hasInline bool
+
+	prefer := scope
+	if strings.HasPrefix(token, "/") {
+		prefer = 0
+	}
+
+	for i := range fields {
+
+		f := &fields[i]
+		name := f.info.Name
+
+		value, ok := "", false
+
+		switch {
+		case strings.EqualFold(token, name):
+			ok = true
+		case f.typ.Kind() == reflect.Bool && len(token) > len(name) && hasPrefixFold(token, name) &&
+			isBoolFormat(f.info, token[len(name):]):
+			value, ok = token[len(name):], true
+		case f.info.Sep == "=" && hasPrefixFold(token, name+"="):
+			value, ok = token[len(name)+1:], true
+		}
+
+		if !ok {
+			continue
+		}
+
+		if found == nil || f.scope == prefer && found.scope != prefer || f.command && !found.command {
+			found, inline, hasInline = f, value, len(value) > 0
+		}
+
+	}
+
+	return found, inline, hasInline
+}
+
+func isBoolFormat(info *marshaler.FieldTagInfo, s string) bool {
+	return len(s) > 0 && (s == info.TrueFormat || s == info.FalseFormat)
+}
+
+// argFieldValue возвращает поле по пути, создавая вложенные структуры
+func argFieldValue(root reflect.Value, path []int) reflect.Value {
+
+	v := root.Elem()
+
+	for _, i := range path {
+
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+// splitRepeatedValue разделяет значение repeatedValue, переданное одним параметром (-f a.cf -f b.cf),
+// см. appendRepeatedValues
+func splitRepeatedValue(v reflect.Value, info *marshaler.FieldTagInfo, value string) []string {
+
+	if _, ok := v.Interface().(repeatedValue); !ok {
+		return []string{value}
+	}
+
+	return strings.Split(value, " "+info.Name+info.Sep)
+}
+
+// setArgValue устанавливает значение параметра.
+// Повторяющийся параметр добавляет элемент в поле-срез или строку в многострочное значение (RepositoryComment),
+// для остальных полей используется последнее значение
+func setArgValue(v reflect.Value, value string) error {
+
+	switch v.Kind() {
+	case reflect.String:
+		if _, ok := v.Interface().(repeatedValue); ok && v.Len() > 0 {
+			value = v.String() + "\n" + value
+		}
+		v.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return errors.Invalid.Newf("unsupported type <%s>", v.Type())
+		}
+		v.Set(reflect.Append(v, reflect.ValueOf(value).Convert(v.Type().Elem())))
+	default:
+		return errors.Invalid.Newf("unsupported type <%s>", v.Type())
+	}
+
+	return nil
+}
+
+// setResultFile заполняет поля /Out, -NoTruncate, /DumpResult и /UC общих параметров Designer.
+// Значение /Out может содержать -NoTruncate, как его передает runner
+func setResultFile(root reflect.Value, key, value string) {
+
+	d := root.Elem().FieldByName("Designer")
+
+	if !d.IsValid() || d.Type() != reflect.TypeOf(Designer{}) {
+		return
+	}
+
+	switch strings.ToUpper(key) {
+	case "/OUT":
+		if i := strings.LastIndex(value, " "); i > 0 && strings.EqualFold(value[i+1:], "-NoTruncate") {
+			d.FieldByName("NoTruncate").SetBool(true)
+			value = strings.TrimSpace(value[:i])
+		}
+		d.FieldByName("Out").SetString(unquoteArg(value))
+	case "/DUMPRESULT":
+		d.FieldByName("DumpResult").SetString(unquoteArg(value))
+	case "/UC":
+		d.FieldByName("AccessCode").SetString(unquoteArg(value))
+	default:
+		d.FieldByName("NoTruncate").SetBool(true)
+	}
+}
+
+// splitArgs разделяет параметры, переданные вместе со значением (/LoadCfg ./1cv8.cf)
+func splitArgs(args []string) []string {
+
+	var tokens []string
+
+	for _, arg := range args {
+
+		arg = strings.TrimSpace(arg)
+
+		if len(arg) == 0 {
+			continue
+		}
+
+		if i := strings.IndexByte(arg, ' '); i > 0 && (arg[0] == '/' || arg[0] == '-') {
+			tokens = append(tokens, arg[:i], strings.TrimSpace(arg[i+1:]))
+			continue
+		}
+
+		tokens = append(tokens, arg)
+
+	}
+
+	return tokens
+}
+
+// unquoteArg убирает кавычки значения: "value", ""value"" (dbl_quotes) или 'value' (quotes)
+func unquoteArg(value string) string {
+
+	for _, q := range []string{`""`, `"`, `'`} {
+		if len(value) >= 2*len(q) && strings.HasPrefix(value, q) && strings.HasSuffix(value, q) {
+			return value[len(q) : len(value)-len(q)]
+		}
+	}
+
+	return value
+}
+
+func quoteConnectionArg(key, value string) string {
+
+	if strings.EqualFold(key, "/IBConnectionString") {
+		return value
+	}
+
+	return `"` + value + `"`
+}
+
+func isExecutable(token string) bool {
+
+	name := strings.ToLower(filepath.Base(strings.Replace(token, `\`, "/", -1)))
+	name = strings.TrimSuffix(name, ".exe")
+
+	return containsFold(parseExecutables, name)
+}
+
+func containsFold(list []string, s string) bool {
+
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
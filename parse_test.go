@@ -0,0 +1,129 @@
+package designer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse_RoundTrip(t *testing.T) {
+
+	repository := Repository{Path: "./repo", User: "admin", Password: "pwd"}
+
+	tests := []struct {
+		name string
+		what interface{ Values() []string }
+	}{
+		{"LoadCfg", LoadCfgOptions{Designer: NewDesigner(), File: "./1cv8.cf"}},
+		{
+			"LoadCfg with UpdateDBCfg",
+			LoadCfgOptions{Designer: NewDesigner(), File: "./1cv8.cf", Extension: "ext"}.
+				WithUpdateDBCfg(UpdateDBCfgOptions{Dynamic: true, Server: true, Extension: "ext"}),
+		},
+		{"UpdateDBCfg", UpdateDBCfgOptions{Designer: NewDesigner(), Dynamic: true, WarningsAsErrors: true}},
+		{
+			"RepositoryUpdateCfg",
+			RepositoryUpdateCfgOptions{Designer: NewDesigner(), Version: 12, Force: true, Objects: "./objects.xml"}.
+				WithRepository(repository),
+		},
+		{
+			"GetChangesForConfigDump",
+			GetChangesForConfigDumpOptions{Designer: NewDesigner(), Dir: "./src", GetChanges: "./changes.txt"}.
+				WithConfigDumpInfo("./src/ConfigDumpInfo.xml"),
+		},
+		{"DumpIB", DumpIBOptions{Designer: NewDesigner(), File: "./1.dt"}},
+		{
+			"CreateDistributionFiles",
+			CreateDistributionFilesOptions{Designer: NewDesigner(), CfuFile: "./1Cv8.cfu"}.
+				WithPreviousReleases("./1.0.1/1Cv8.cf", "./1.0.2/1Cv8.cf", "./1,0,3/1Cv8.cf").
+				WithPreviousVersions("1.0.0.1", "1.0.0.2"),
+		},
+		{
+			"RepositoryCommit",
+			RepositoryCommitOptions{Designer: NewDesigner(), KeepLocked: true}.
+				WithComment("first line\nsecond line").
+				WithRepository(repository),
+		},
+		{
+			"RepositorySetLabel",
+			RepositorySetLabelOptions{Designer: NewDesigner(), Label: "1.0.1"}.
+				WithComment("first line\nsecond line").
+				WithRepository(repository),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			want := tt.what.Values()
+
+			got, err := Parse(want)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if reflect.TypeOf(got).Elem() != reflect.TypeOf(tt.what) {
+				t.Errorf("Parse() = %T, want %T", got, tt.what)
+			}
+
+			if !reflect.DeepEqual(got.Values(), want) {
+				t.Errorf("Parse().Values() = %v, want %v", got.Values(), want)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+
+	args := []string{
+		"1cv8.exe", "DESIGNER", "/F", "./ib", "/N", "admin", "/P", "pwd", "/DisableStartupDialogs",
+		"/LoadCfg", `"./1cv8.cf"`, "/UpdateDBCfg", "-Dynamic-", "-Server",
+		"/Out ./out.txt -NoTruncate", "/DumpResult", "./result.txt", "/UC", "123",
+	}
+
+	got, err := ParseArgs(args)
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+
+	if got.Mode != COMMAND_DESIGNER || got.User != "admin" || got.Password != "pwd" || got.UnlockCode != "123" {
+		t.Errorf("ParseArgs() = %v/%v/%v/%v", got.Mode, got.User, got.Password, got.UnlockCode)
+	}
+
+	if got.Infobase == nil || got.Infobase.ConnectionString() != NewFileInfobase("./ib").ConnectionString() {
+		t.Errorf("ParseArgs() Infobase = %v", got.Infobase)
+	}
+
+	want := []string{"/DisableStartupDialogs", "/LoadCfg ./1cv8.cf", "/UpdateDBCfg", "-Server"}
+
+	if !reflect.DeepEqual(got.Command.Values(), want) {
+		t.Errorf("ParseArgs() Values() = %v, want %v", got.Command.Values(), want)
+	}
+
+	d := got.Command.(*LoadCfgOptions).Designer
+
+	if d.Out != "./out.txt" || !d.NoTruncate || d.DumpResult != "./result.txt" || d.AccessCode != "123" {
+		t.Errorf("ParseArgs() Designer = %+v", d)
+	}
+
+}
+
+func TestParse_Errors(t *testing.T) {
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no command", []string{"/DisableStartupDialogs"}},
+		{"unknown parameter", []string{"/LoadCfg", "./1cv8.cf", "-Files", "a.xml"}},
+		{"unexpected argument", []string{"/LoadCfg", "./1cv8.cf", "./2.cf"}},
+		{"no value", []string{"/LoadCfg"}},
+		{"bad int", []string{"/ConfigurationRepositoryUpdateCfg", "-v", "last"}},
+		{"create infobase", []string{"CREATEINFOBASE", "File=./ib"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Parse(tt.args); err == nil {
+				t.Errorf("Parse() = %v, want error", got.Values())
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package designer
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"time"
+)
+
+type StepStatus string
+
+const (
+	STEP_STATUS_SUCCESS     StepStatus = "success"
+	STEP_STATUS_FAILED      StepStatus = "failed"
+	STEP_STATUS_SKIPPED     StepStatus = "skipped"
+	STEP_STATUS_ROLLED_BACK StepStatus = "rolled_back"
+)
+
+// DEFAULT_ROLLBACK_TIMEOUT ограничение времени выполнения команды отката по умолчанию, см. Pipeline.RollbackTimeout
+const DEFAULT_ROLLBACK_TIMEOUT = time.Hour
+
+// PipelineStep шаг конвейера команд
+type PipelineStep struct {
+	// Name наименование шага для отчета
+	Name string `json:"name"`
+
+	// Command команда конфигуратора или 1С:Предприятия
+	Command runner.Command `json:"-"`
+
+	// Timeout ограничение времени выполнения шага. 0 — без ограничения
+	Timeout time.Duration `json:"timeout"`
+
+	// ContinueOnError при ошибке шага продолжать выполнение следующих шагов
+	ContinueOnError bool `json:"continue_on_error"`
+
+	// Rollback команды отката шага, например RestoreIBOptions из резервной копии, созданной предыдущим шагом.
+	// Выполняются, если шаг или один из следующих шагов завершился ошибкой
+	Rollback []runner.Command `json:"-"`
+
+	// Options дополнительные параметры запуска шага (runner.Option)
+	Options []interface{} `json:"-"`
+}
+
+// NewStep создает шаг конвейера
+func NewStep(name string, what runner.Command) PipelineStep {
+
+	return PipelineStep{
+		Name:    name,
+		Command: what,
+	}
+}
+
+func (s PipelineStep) WithTimeout(timeout time.Duration) PipelineStep {
+
+	newS := s
+	newS.Timeout = timeout
+	return newS
+}
+
+func (s PipelineStep) WithContinueOnError() PipelineStep {
+
+	newS := s
+	newS.ContinueOnError = true
+	return newS
+}
+
+func (s PipelineStep) WithRollback(rollback ...runner.Command) PipelineStep {
+
+	newS := s
+	newS.Rollback = append(append([]runner.Command{}, s.Rollback...), rollback...)
+	return newS
+}
+
+func (s PipelineStep) WithOptions(opts ...interface{}) PipelineStep {
+
+	newS := s
+	newS.Options = append(append([]interface{}{}, s.Options...), opts...)
+	return newS
+}
+
+// StepResult результат выполнения шага конвейера
+type StepResult struct {
+	Name string `json:"name"`
+
+	// Args параметры запуска команды. Пароли и коды доступа скрыты, см. MaskValues
+	Args []string `json:"args"`
+
+	Status StepStatus `json:"status"`
+
+	Err error `json:"-"`
+
+	// Error текст ошибки для сериализации отчета
+	Error string `json:"error,omitempty"`
+
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+
+	// Out содержимое файла /Out
+	Out string `json:"out"`
+
+	// Rollback результаты выполнения команд отката шага
+	Rollback []StepResult `json:"rollback,omitempty"`
+}
+
+// PipelineReport отчет о выполнении конвейера
+type PipelineReport struct {
+	Steps []StepResult `json:"steps"`
+
+	// Success все шаги, кроме шагов с ContinueOnError, выполнены успешно
+	Success bool `json:"success"`
+
+	// RolledBack выполнен откат шагов
+	RolledBack bool `json:"rolled_back"`
+}
+
+// Failed возвращает шаги, завершившиеся ошибкой
+func (r PipelineReport) Failed() []StepResult {
+
+	var failed []StepResult
+
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			failed = append(failed, step)
+		}
+	}
+
+	return failed
+}
+
+// PipelineError ошибка шага, прервавшего выполнение конвейера
+type PipelineError struct {
+	Step string
+	Err  error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("pipeline step <%s>: %v", e.Step, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline конвейер команд, выполняемых последовательно в одной информационной базе.
+//
+// Пример:
+//
+//	p := NewPipeline(NewFileInfobase("./ib"), runner.WithCredentials("admin", ""))
+//	p.Add(
+//		NewStep("backup", DumpIBOptions{Designer: NewDesigner(), File: "./backup.dt"}),
+//		NewStep("load", LoadCfgOptions{Designer: NewDesigner(), File: "./1cv8.cf"}).
+//			WithTimeout(time.Hour).
+//			WithRollback(RestoreIBOptions{Designer: NewDesigner(), File: "./backup.dt"}),
+//		NewStep("update", UpdateDBCfgOptions{Designer: NewDesigner()}).
+//			WithRollback(RestoreIBOptions{Designer: NewDesigner(), File: "./backup.dt"}),
+//		NewStep("dump", DumpIBOptions{Designer: NewDesigner(), File: "./result.dt"}).
+//			WithContinueOnError(),
+//	)
+//	report, err := p.Execute(ctx)
+type Pipeline struct {
+	Infobase runner.Infobase `json:"-"`
+
+	Steps []PipelineStep `json:"steps"`
+
+	// Options общие параметры запуска всех шагов (runner.Option)
+	Options []interface{} `json:"-"`
+
+	// Run функция запуска команды. По умолчанию запуск через runner
+	Run RunFunc `json:"-"`
+
+	// RollbackTimeout ограничение времени выполнения каждой команды отката.
+	// Откат выполняется в собственном контексте и не прерывается отменой контекста Execute.
+	// 0 — используется Timeout шага
+	RollbackTimeout time.Duration `json:"rollback_timeout"`
+}
+
+// NewPipeline создает конвейер команд для информационной базы
+func NewPipeline(where runner.Infobase, opts ...interface{}) *Pipeline {
+
+	return &Pipeline{
+		Infobase:        where,
+		Options:         opts,
+		RollbackTimeout: DEFAULT_ROLLBACK_TIMEOUT,
+	}
+}
+
+// Add добавляет шаги в конец конвейера
+func (p *Pipeline) Add(steps ...PipelineStep) *Pipeline {
+
+	p.Steps = append(p.Steps, steps...)
+	return p
+}
+
+// Check проверяет команды всех шагов и команды отката
+func (p *Pipeline) Check() error {
+
+	var err multierror.Error
+
+	for _, step := range p.Steps {
+
+		if step.Command == nil {
+			multierror.Append(&err, errors.Check.Newf("pipeline step <%s> has no command", step.Name))
+		} else if checkErr := step.Command.Check(); checkErr != nil {
+			multierror.Append(&err, errors.Wrapf(checkErr, "pipeline step <%s>", step.Name))
+		}
+
+		for _, rollback := range step.Rollback {
+			if checkErr := rollback.Check(); checkErr != nil {
+				multierror.Append(&err, errors.Wrapf(checkErr, "pipeline step <%s> rollback", step.Name))
+			}
+		}
+
+	}
+
+	return err.ErrorOrNil()
+}
+
+// Execute выполняет шаги конвейера по порядку.
+//
+// Если шаг без ContinueOnError завершился ошибкой, следующие шаги пропускаются,
+// а команды отката выполняются в обратном порядке для этого шага и всех выполненных до него.
+// Откат выполняется, даже если контекст ctx отменен, см. RollbackTimeout.
+// Ошибка запуска команды отката записывается в результаты отката шага и не прерывает откат предыдущих шагов.
+// Возвращается отчет по всем шагам и ошибка первого шага, прервавшего выполнение
+func (p *Pipeline) Execute(ctx context.Context) (PipelineReport, error) {
+
+	if err := p.Check(); err != nil {
+		return PipelineReport{}, err
+	}
+
+	report := PipelineReport{Success: true}
+
+	var failErr error
+	failed := -1
+
+	for i, step := range p.Steps {
+
+		if failed >= 0 {
+			report.Steps = append(report.Steps, StepResult{
+				Name:   step.Name,
+				Args:   MaskValues(step.Command.Values()),
+				Status: STEP_STATUS_SKIPPED,
+			})
+			continue
+		}
+
+		result, err := p.runStep(ctx, step.Name, step.Command, step.Timeout, step.Options)
+
+		if err != nil {
+			return report, err
+		}
+
+		report.Steps = append(report.Steps, result)
+
+		if result.Err != nil && !step.ContinueOnError {
+			failed = i
+			failErr = &PipelineError{Step: step.Name, Err: result.Err}
+		}
+
+	}
+
+	if failed < 0 {
+		return report, nil
+	}
+
+	report.Success = false
+
+	rollbackTimeout := p.RollbackTimeout
+
+	for i := failed; i >= 0; i-- {
+
+		step := p.Steps[i]
+
+		timeout := rollbackTimeout
+		if timeout == 0 {
+			timeout = step.Timeout
+		}
+
+		for _, rollback := range step.Rollback {
+
+			name := step.Name + " rollback"
+
+			result, err := p.runStep(context.Background(), name, rollback, timeout, step.Options)
+
+			// ошибка запуска отката не прерывает откат предыдущих шагов
+			if err != nil {
+				result = StepResult{
+					Name:    name,
+					Args:    MaskValues(rollback.Values()),
+					Status:  STEP_STATUS_FAILED,
+					Started: time.Now(),
+					Err:     err,
+					Error:   err.Error(),
+				}
+			}
+
+			report.Steps[i].Rollback = append(report.Steps[i].Rollback, result)
+
+		}
+
+		if len(step.Rollback) == 0 {
+			continue
+		}
+
+		if report.Steps[i].Status == STEP_STATUS_SUCCESS {
+			report.Steps[i].Status = STEP_STATUS_ROLLED_BACK
+		}
+
+		report.RolledBack = true
+
+	}
+
+	return report, failErr
+}
+
+func (p *Pipeline) runStep(ctx context.Context, name string, what runner.Command,
+	timeout time.Duration, opts []interface{}) (StepResult, error) {
+
+	stepCtx := ctx
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		stepCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result := StepResult{
+		Name:    name,
+		Args:    MaskValues(what.Values()),
+		Status:  STEP_STATUS_SUCCESS,
+		Started: time.Now(),
+	}
+
+	runOpts := append(append([]interface{}{}, p.Options...), opts...)
+
+	captured, err := runCapture(stepCtx, p.Run, p.Infobase, what, runOpts)
+
+	if err != nil {
+		return StepResult{}, err
+	}
+
+	result.Duration = time.Since(result.Started)
+	result.Out = captured.Out
+
+	if captured.Err != nil {
+		result.Status = STEP_STATUS_FAILED
+		result.Err = captured.Err
+		result.Error = captured.Err.Error()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,246 @@
+package designer
+
+import (
+	"context"
+	stderrors "errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/runner"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordRun возвращает функцию запуска, которая сохраняет первый параметр команды
+// и завершается ошибкой для команд из fail
+func recordRun(executed *[]string, fail map[string]string) RunFunc {
+
+	return func(ctx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error {
+
+		options := runner.Options{}
+
+		for _, opt := range opts {
+			if fn, ok := opt.(runner.Option); ok {
+				fn(&options)
+			}
+		}
+
+		values := what.Values()
+		key := values[len(values)-1]
+		*executed = append(*executed, key)
+
+		if msg, ok := fail[key]; ok {
+			_ = ioutil.WriteFile(options.Out, []byte(msg), 0644)
+			_ = ioutil.WriteFile(options.DumpResult, []byte("1"), 0644)
+			return nil
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < time.Minute {
+			return context.DeadlineExceeded
+		}
+
+		_ = ioutil.WriteFile(options.DumpResult, []byte("0"), 0644)
+		return nil
+	}
+}
+
+func TestPipeline_Execute(t *testing.T) {
+
+	backup := DumpIBOptions{Designer: NewDesigner(), File: "./backup.dt"}
+	restore := RestoreIBOptions{Designer: NewDesigner(), File: "./backup.dt"}
+
+	steps := []PipelineStep{
+		NewStep("backup", backup),
+		NewStep("load", LoadCfgOptions{Designer: NewDesigner(), File: "./1cv8.cf"}).
+			WithRollback(restore),
+		NewStep("update", UpdateDBCfgOptions{Designer: NewDesigner(), Server: true}),
+		NewStep("dump", DumpIBOptions{Designer: NewDesigner(), File: "./result.dt"}).
+			WithContinueOnError().
+			WithTimeout(time.Second),
+	}
+
+	tests := []struct {
+		name         string
+		fail         map[string]string
+		wantExecuted []string
+		wantStatus   []StepStatus
+		wantErr      error
+	}{
+		{
+			"success",
+			nil,
+			[]string{"/DumpIB ./backup.dt", "/LoadCfg ./1cv8.cf", "-Server", "/DumpIB ./result.dt"},
+			[]StepStatus{STEP_STATUS_SUCCESS, STEP_STATUS_SUCCESS, STEP_STATUS_SUCCESS, STEP_STATUS_FAILED},
+			nil,
+		},
+		{
+			"rollback",
+			map[string]string{"-Server": "Ошибка блокировки информационной базы для конфигурирования"},
+			[]string{"/DumpIB ./backup.dt", "/LoadCfg ./1cv8.cf", "-Server", "/RestoreIB ./backup.dt"},
+			[]StepStatus{STEP_STATUS_SUCCESS, STEP_STATUS_ROLLED_BACK, STEP_STATUS_FAILED, STEP_STATUS_SKIPPED},
+			ErrInfobaseLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var executed []string
+
+			p := NewPipeline(NewFileInfobase("./ib"))
+			p.Run = recordRun(&executed, tt.fail)
+			p.Add(steps...)
+
+			report, err := p.Execute(context.Background())
+
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(executed, tt.wantExecuted) {
+				t.Errorf("Execute() executed = %v, want %v", executed, tt.wantExecuted)
+			}
+
+			var status []StepStatus
+			for _, step := range report.Steps {
+				status = append(status, step.Status)
+			}
+
+			if !reflect.DeepEqual(status, tt.wantStatus) {
+				t.Errorf("Execute() status = %v, want %v", status, tt.wantStatus)
+			}
+
+			if report.Success != (tt.wantErr == nil) || report.RolledBack != (tt.wantErr != nil) {
+				t.Errorf("Execute() report = %+v", report)
+			}
+		})
+	}
+}
+
+func TestPipeline_RollbackAfterCancel(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repository := Repository{Path: "./repo", User: "admin", Password: "secret"}
+
+	var executed []string
+
+	p := NewPipeline(NewFileInfobase("./ib"))
+	p.Run = func(runCtx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error {
+
+		if err := runCtx.Err(); err != nil {
+			return err
+		}
+
+		run := recordRun(&executed, map[string]string{"/ConfigurationRepositoryUpdateCfg": "Ошибка обновления"})
+		err := run(runCtx, where, what, opts...)
+		cancel()
+		return err
+	}
+	p.Add(
+		NewStep("update", RepositoryUpdateCfgOptions{Designer: NewDesigner()}.WithRepository(repository)).
+			WithRollback(RestoreIBOptions{Designer: NewDesigner(), File: "./backup.dt"}),
+	)
+
+	report, err := p.Execute(ctx)
+
+	if err == nil {
+		t.Fatalf("Execute() error = nil, want step error")
+	}
+
+	want := []string{"/ConfigurationRepositoryUpdateCfg", "/RestoreIB ./backup.dt"}
+
+	if !reflect.DeepEqual(executed, want) {
+		t.Errorf("Execute() executed = %v, want %v", executed, want)
+	}
+
+	step := report.Steps[0]
+
+	if len(step.Rollback) != 1 || step.Rollback[0].Err != nil || step.Status != STEP_STATUS_FAILED {
+		t.Errorf("Execute() step = %+v, want successful rollback", step)
+	}
+
+	for _, arg := range step.Args {
+		if arg == "/ConfigurationRepositoryP secret" {
+			t.Errorf("Execute() Args = %v, want masked password", step.Args)
+		}
+	}
+
+	if !containsFold(step.Args, "/ConfigurationRepositoryP "+PASSWORD_MASK) {
+		t.Errorf("Execute() Args = %v, want %v", step.Args, PASSWORD_MASK)
+	}
+}
+
+func TestPipeline_RollbackRunError(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "v8_pipeline_")
+	defer os.RemoveAll(dir)
+
+	tmp := os.Getenv("TMPDIR")
+	defer os.Setenv("TMPDIR", tmp)
+
+	// откат первого шага использует собственные файлы /Out и /DumpResult
+	restore := RestoreIBOptions{Designer: NewDesigner(), File: "./backup.dt"}
+	restore.Out = filepath.Join(dir, "out.txt")
+	restore.DumpResult = filepath.Join(dir, "result.txt")
+
+	var executed []string
+
+	p := NewPipeline(NewFileInfobase("./ib"))
+	p.Run = func(ctx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error {
+
+		err := recordRun(&executed, map[string]string{"-Server": "Ошибка обновления"})(ctx, where, what, opts...)
+
+		if values := what.Values(); values[len(values)-1] == "-Server" {
+			// временные файлы для отката второго шага создать не удастся
+			_ = os.Setenv("TMPDIR", filepath.Join(dir, "missing"))
+		}
+		return err
+	}
+	p.Add(
+		NewStep("load", LoadCfgOptions{Designer: NewDesigner(), File: "./1cv8.cf"}).WithRollback(restore),
+		NewStep("update", UpdateDBCfgOptions{Designer: NewDesigner(), Server: true}).
+			WithRollback(DumpIBOptions{Designer: NewDesigner(), File: "./failed.dt"}),
+	)
+
+	report, err := p.Execute(context.Background())
+
+	var stepErr *PipelineError
+
+	if !stderrors.As(err, &stepErr) || stepErr.Step != "update" {
+		t.Fatalf("Execute() error = %v, want update step error", err)
+	}
+
+	want := []string{"/LoadCfg ./1cv8.cf", "-Server", "/RestoreIB ./backup.dt"}
+
+	if !reflect.DeepEqual(executed, want) {
+		t.Errorf("Execute() executed = %v, want %v", executed, want)
+	}
+
+	if rollback := report.Steps[1].Rollback; len(rollback) != 1 || rollback[0].Err == nil ||
+		rollback[0].Status != STEP_STATUS_FAILED {
+		t.Errorf("Execute() update rollback = %+v, want run error", rollback)
+	}
+
+	if rollback := report.Steps[0].Rollback; len(rollback) != 1 || rollback[0].Err != nil {
+		t.Errorf("Execute() load rollback = %+v, want success", rollback)
+	}
+}
+
+func TestPipeline_Check(t *testing.T) {
+
+	p := NewPipeline(NewFileInfobase("./ib")).Add(
+		NewStep("empty", nil),
+		NewStep("dump", DumpIBOptions{}).WithRollback(RestoreIBOptions{}),
+	)
+
+	err := p.Check()
+
+	var multi *multierror.Error
+
+	if !stderrors.As(err, &multi) || len(multi.Errors) != 3 {
+		t.Errorf("Check() error = %v, want 3 errors", err)
+	}
+}
@@ -0,0 +1,138 @@
+package designer
+
+import (
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CommandFactory создает указатель на новое значение команды с параметрами по умолчанию
+type CommandFactory func() runner.Command
+
+type registryEntry struct {
+	name    string
+	factory CommandFactory
+}
+
+var registry = make(map[string]registryEntry)
+
+func init() {
+
+	designer := func(fn func(d Designer) runner.Command) CommandFactory {
+		return func() runner.Command {
+			return fn(NewDesigner())
+		}
+	}
+
+	for _, fn := range []CommandFactory{
+		func() runner.Command { return &AgentModeOptions{} },
+		func() runner.Command { return &CreateFileInfoBaseOptions{} },
+		func() runner.Command { return &CreateServerInfoBaseOptions{} },
+		func() runner.Command { e := NewExecute(""); return &e },
+		designer(func(d Designer) runner.Command { return &UpdateCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &UpdateDBCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &CheckCanApplyExtensionsOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &CheckConfigOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &CheckModulesOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &CompareCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &CreateDistributionFilesOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &CreateDistributiveOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &DumpCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &DumpConfigToFilesOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &GetChangesForConfigDumpOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &DumpExternalDataFileToFilesOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &DeleteCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &DumpDBCfgListOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &ManageCfgExtensionsOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &DumpIBOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RestoreIBOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &LoadCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &LoadConfigFromFiles{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &LoadExternalDataFileFromFilesOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &MergeCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryCreateOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryClearGlobalCacheOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryClearCacheOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryClearLocalCacheOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryOptimizeDataOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryBindCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryUnbindCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryDumpCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryUpdateCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryLockOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryUnlockOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryCommitOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryReportOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositorySetLabelOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryAddUserOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RepositoryCopyUsersOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &IBRestoreIntegrityOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &RollbackCfgOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &ManageCfgSupportOptions{Designer: d} }),
+		designer(func(d Designer) runner.Command { return &ReduceEventLogSizeOptions{Designer: d} }),
+	} {
+		Register(CommandName(fn()), fn)
+	}
+
+}
+
+// Register регистрирует команду под именем name.
+// Имя сравнивается без учета регистра, зарегистрированная ранее команда заменяется
+func Register(name string, factory CommandFactory) {
+	registry[strings.ToLower(name)] = registryEntry{name, factory}
+}
+
+// Lookup возвращает фабрику команды по имени.
+// Имя команды — имя типа без суффикса Options, например LoadCfg, UpdateDBCfg, LoadConfigFromFiles.
+// Допускается указание полного имени типа (LoadCfgOptions)
+func Lookup(name string) (CommandFactory, bool) {
+
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if entry, ok := registry[name]; ok {
+		return entry.factory, true
+	}
+
+	entry, ok := registry[strings.TrimSuffix(name, "options")]
+	return entry.factory, ok
+}
+
+// NewCommand создает команду с параметрами по умолчанию по имени
+func NewCommand(name string) (runner.Command, error) {
+
+	factory, ok := Lookup(name)
+
+	if !ok {
+		return nil, errors.NotExist.Newf("unknown command <%s>", name)
+	}
+
+	return factory(), nil
+}
+
+// Commands возвращает имена зарегистрированных команд
+func Commands() []string {
+
+	names := make([]string, 0, len(registry))
+
+	for _, entry := range registry {
+		names = append(names, entry.name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// CommandName возвращает имя команды, под которым она регистрируется: имя типа без суффикса Options
+func CommandName(what runner.Command) string {
+
+	t := reflect.TypeOf(what)
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return strings.TrimSuffix(t.Name(), "Options")
+}
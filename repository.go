@@ -57,6 +57,17 @@ func (r Repository) Values() []string {
 
 }
 
+// Check проверяет параметры подключения к хранилищу: каталог хранилища обязателен
+func (r Repository) Check() error {
+
+	if len(r.Path) == 0 {
+		return errors.Check.New("repository path must be set").
+			WithContext("msg", "field Path not set")
+	}
+
+	return nil
+}
+
 //ConfigurationRepositoryCreate
 ///ConfigurationRepositoryCreate [-Extension <имя расширения>] [-AllowConfigurationChanges
 //-ChangesAllowedRule <Правило поддержки> -ChangesNotRecommendedRule <Правило поддержки>] [-NoBind]
@@ -131,10 +142,14 @@ func (ib RepositoryCreateOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: при разрешении изменений конфигурации
+// обязательны правила -ChangesAllowedRule и -ChangesNotRecommendedRule
 func (ib RepositoryCreateOptions) Check() error {
 
 	var err multierror.Error
 
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
 	if ib.AllowConfigurationChanges && (len(ib.ChangesNotRecommendedRule) == 0 || len(ib.ChangesAllowedRule) == 0) {
 
 		multierror.Append(&err, errors.Check.New("configuration changes must be set").
@@ -1,7 +1,10 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
+	"github.com/v8platform/runner"
 )
 
 ///ConfigurationRepositoryClearGlobalCache [-Extension <имя расширения>]
@@ -21,6 +24,16 @@ func (ib RepositoryClearGlobalCacheOptions) Values() []string {
 
 }
 
+func (ib RepositoryClearGlobalCacheOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryClearGlobalCacheOptions) WithRepository(repository Repository) RepositoryClearGlobalCacheOptions {
 
 	newO := o
@@ -48,6 +61,16 @@ func (ib RepositoryClearCacheOptions) Values() []string {
 
 }
 
+func (ib RepositoryClearCacheOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryClearCacheOptions) WithRepository(repository Repository) RepositoryClearCacheOptions {
 
 	newO := o
@@ -75,6 +98,16 @@ func (ib RepositoryClearLocalCacheOptions) Values() []string {
 
 }
 
+func (ib RepositoryClearLocalCacheOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryClearLocalCacheOptions) WithRepository(repository Repository) RepositoryClearLocalCacheOptions {
 
 	newO := o
@@ -84,3 +117,114 @@ func (o RepositoryClearLocalCacheOptions) WithRepository(repository Repository)
 	return newO
 
 }
+
+///ConfigurationRepositoryOptimizeData [-Extension <имя расширения>]
+//— оптимизация хранения данных хранилища конфигурации.
+type RepositoryOptimizeDataOptions struct {
+	Designer   `v8:",inherit" json:"designer"`
+	Repository `v8:",inherit" json:"repository"`
+
+	command struct{} `v8:"/ConfigurationRepositoryOptimizeData" json:"-"`
+}
+
+func (ib RepositoryOptimizeDataOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(ib)
+	fixExtensionIndex(&v)
+	return v
+
+}
+
+func (ib RepositoryOptimizeDataOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
+func (o RepositoryOptimizeDataOptions) WithRepository(repository Repository) RepositoryOptimizeDataOptions {
+
+	newO := o
+	newO.Path = repository.Path
+	newO.User = repository.User
+	newO.Password = repository.Password
+	return newO
+
+}
+
+func (r Repository) OptimizeData() RepositoryOptimizeDataOptions {
+
+	return RepositoryOptimizeDataOptions{
+		Designer:   NewDesigner(),
+		Repository: r,
+	}
+
+}
+
+// RepositoryMaintenance пакет команд обслуживания хранилища конфигурации.
+// Команды выполняются в порядке: очистка локального кэша версий, очистка локальной базы данных хранилища,
+// очистка глобального кэша версий, оптимизация хранения данных.
+type RepositoryMaintenance struct {
+	ClearLocalCache  RepositoryClearLocalCacheOptions  `json:"clear_local_cache"`
+	ClearCache       RepositoryClearCacheOptions       `json:"clear_cache"`
+	ClearGlobalCache RepositoryClearGlobalCacheOptions `json:"clear_global_cache"`
+	OptimizeData     RepositoryOptimizeDataOptions     `json:"optimize_data"`
+}
+
+// Commands возвращает команды пакета в порядке выполнения
+func (m RepositoryMaintenance) Commands() []runner.Command {
+
+	return []runner.Command{
+		m.ClearLocalCache,
+		m.ClearCache,
+		m.ClearGlobalCache,
+		m.OptimizeData,
+	}
+
+}
+
+// Check проверяет параметры всех команд пакета
+func (m RepositoryMaintenance) Check() error {
+
+	var err multierror.Error
+
+	for _, cmd := range m.Commands() {
+		multierror.Append(&err, cmd.Check())
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+// Run последовательно выполняет команды пакета для информационной базы.
+// Выполнение прерывается на первой команде, завершившейся с ошибкой.
+func (m RepositoryMaintenance) Run(where runner.Infobase, opts ...interface{}) error {
+
+	for _, cmd := range m.Commands() {
+
+		err := runner.Run(where, cmd, opts...)
+
+		if err != nil {
+			return errors.Wrapf(err, "repository maintenance failed on %T", cmd)
+		}
+	}
+
+	return nil
+
+}
+
+func (r Repository) Maintain() RepositoryMaintenance {
+
+	designer := NewDesigner()
+
+	return RepositoryMaintenance{
+		ClearLocalCache:  RepositoryClearLocalCacheOptions{Designer: designer, Repository: r},
+		ClearCache:       RepositoryClearCacheOptions{Designer: designer, Repository: r},
+		ClearGlobalCache: RepositoryClearGlobalCacheOptions{Designer: designer, Repository: r},
+		OptimizeData:     RepositoryOptimizeDataOptions{Designer: designer, Repository: r},
+	}
+
+}
@@ -0,0 +1,34 @@
+package designer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRepository_Maintain(t *testing.T) {
+
+	repo := Repository{
+		Path:      "./repo",
+		User:      "admin",
+		Extension: "temp_ext",
+	}
+
+	want := [][]string{
+		{"/DisableStartupDialogs", "/DisableStartupMessages", "/ConfigurationRepositoryF ./repo", "/ConfigurationRepositoryN admin", "/ConfigurationRepositoryClearLocalCache", "-Extension temp_ext"},
+		{"/DisableStartupDialogs", "/DisableStartupMessages", "/ConfigurationRepositoryF ./repo", "/ConfigurationRepositoryN admin", "/ConfigurationRepositoryClearCache", "-Extension temp_ext"},
+		{"/DisableStartupDialogs", "/DisableStartupMessages", "/ConfigurationRepositoryF ./repo", "/ConfigurationRepositoryN admin", "/ConfigurationRepositoryClearGlobalCache", "-Extension temp_ext"},
+		{"/DisableStartupDialogs", "/DisableStartupMessages", "/ConfigurationRepositoryF ./repo", "/ConfigurationRepositoryN admin", "/ConfigurationRepositoryOptimizeData", "-Extension temp_ext"},
+	}
+
+	commands := repo.Maintain().Commands()
+
+	if len(commands) != len(want) {
+		t.Fatalf("Commands() len = %d, want %d", len(commands), len(want))
+	}
+
+	for i, cmd := range commands {
+		if got := cmd.Values(); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Commands()[%d].Values() = %v, want %v", i, got, want[i])
+		}
+	}
+}
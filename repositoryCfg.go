@@ -1,6 +1,8 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 )
 
@@ -38,6 +40,16 @@ func (ib RepositoryBindCfgOptions) Values() []string {
 
 }
 
+func (ib RepositoryBindCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (r Repository) Bind(force ...bool) RepositoryBindCfgOptions {
 
 	command := RepositoryBindCfgOptions{
@@ -91,6 +103,16 @@ func (ib RepositoryUnbindCfgOptions) Values() []string {
 
 }
 
+func (ib RepositoryUnbindCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (r Repository) Unbind(force ...bool) RepositoryUnbindCfgOptions {
 
 	command := RepositoryUnbindCfgOptions{
@@ -134,6 +156,22 @@ func (ib RepositoryDumpCfgOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: файл выгрузки обязателен
+func (ib RepositoryDumpCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	if len(ib.File) == 0 {
+		multierror.Append(&err, errors.Check.New("dump file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (r Repository) DumpCfg(file string, version ...int64) RepositoryDumpCfgOptions {
 
 	command := RepositoryDumpCfgOptions{
@@ -213,6 +251,16 @@ func (ib RepositoryUpdateCfgOptions) Values() []string {
 
 }
 
+func (ib RepositoryUpdateCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryUpdateCfgOptions) WithObjects(objectsFile string) RepositoryUpdateCfgOptions {
 
 	newO := o
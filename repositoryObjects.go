@@ -0,0 +1,285 @@
+package designer
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/marshaler"
+	"strings"
+)
+
+// RepositoryComment комментарий к версии хранилища.
+// Для каждой строки многострочного комментария указывается свой ключ -comment,
+// все строки передаются одним значением: -comment <строка 1> -comment <строка 2>.
+type RepositoryComment string
+
+func (t RepositoryComment) items() []string {
+
+	if len(t) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(string(t), "\r\n", "\n"), "\n")
+}
+
+///ConfigurationRepositoryLock [-Extension <имя расширения>] [-objects <имя файла со списком объектов>] [-revised]
+//— захват объектов в хранилище конфигурации для редактирования.
+//
+//-Extension <имя расширения> — Имя расширения. Если параметр не указан,
+// выполняется попытка соединения с хранилищем основной конфигурации, и команда выполняется для основной конфигурации.
+// Если параметр указан, выполняется попытка соединения с хранилищем указанного расширения, и команда выполняется для этого хранилища.
+//
+//-objects <имя файла со списком объектов> — путь к файлу формата XML со списком объектов.
+//Если параметр используется, будет выполнена попытка захвата только объектов, указанных в файле.
+//Если параметр не используется, будут захвачены все объекты конфигурации.
+//
+//-revised — получать захваченные объекты, если потребуется.
+type RepositoryLockOptions struct {
+	Designer   `v8:",inherit" json:"designer"`
+	Repository `v8:",inherit" json:"repository"`
+
+	command struct{} `v8:"/ConfigurationRepositoryLock" json:"-"`
+
+	//-objects <имя файла со списком объектов> — путь к файлу формата XML со списком объектов.
+	//Если параметр используется, будет выполнена попытка захвата только объектов, указанных в файле.
+	//Если параметр не используется, будут захвачены все объекты конфигурации.
+	Objects string `v8:"-objects, optional" json:"objects"`
+
+	//-revised — получать захваченные объекты, если потребуется.
+	Revised bool `v8:"-revised, optional" json:"revised"`
+}
+
+func (ib RepositoryLockOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(ib)
+	fixExtensionIndex(&v)
+	return v
+
+}
+
+func (ib RepositoryLockOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
+func (o RepositoryLockOptions) WithObjects(objectsFile string) RepositoryLockOptions {
+
+	newO := o
+	newO.Objects = objectsFile
+	return newO
+
+}
+
+func (o RepositoryLockOptions) WithRepository(repository Repository) RepositoryLockOptions {
+
+	newO := o
+	newO.Path = repository.Path
+	newO.User = repository.User
+	newO.Password = repository.Password
+	return newO
+
+}
+
+func (r Repository) Lock(revised ...bool) RepositoryLockOptions {
+
+	command := RepositoryLockOptions{
+		Designer:   NewDesigner(),
+		Repository: r,
+	}
+
+	if len(revised) > 0 {
+		command.Revised = revised[0]
+	}
+
+	return command
+
+}
+
+///ConfigurationRepositoryUnlock [-Extension <имя расширения>] [-objects <имя файла со списком объектов>] [-force]
+//— отмена захвата объектов в хранилище конфигурации.
+//
+//-Extension <имя расширения> — Имя расширения. Если параметр не указан,
+// выполняется попытка соединения с хранилищем основной конфигурации, и команда выполняется для основной конфигурации.
+// Если параметр указан, выполняется попытка соединения с хранилищем указанного расширения, и команда выполняется для этого хранилища.
+//
+//-objects <имя файла со списком объектов> — путь к файлу формата XML со списком объектов.
+//Если параметр используется, будет выполнена попытка отмены захвата только для объектов, указанных в файле.
+//Если параметр не используется, захват будет отменен для всех объектов конфигурации.
+//
+//-force — если захваченные объекты были изменены, изменения будут потеряны.
+//Если параметр не указан, отмена захвата измененных объектов выполнена не будет.
+type RepositoryUnlockOptions struct {
+	Designer   `v8:",inherit" json:"designer"`
+	Repository `v8:",inherit" json:"repository"`
+
+	command struct{} `v8:"/ConfigurationRepositoryUnlock" json:"-"`
+
+	//-objects <имя файла со списком объектов> — путь к файлу формата XML со списком объектов.
+	//Если параметр используется, будет выполнена попытка отмены захвата только для объектов, указанных в файле.
+	//Если параметр не используется, захват будет отменен для всех объектов конфигурации.
+	Objects string `v8:"-objects, optional" json:"objects"`
+
+	//-force — если захваченные объекты были изменены, изменения будут потеряны.
+	//Если параметр не указан, отмена захвата измененных объектов выполнена не будет.
+	Force bool `v8:"-force, optional" json:"force"`
+}
+
+func (ib RepositoryUnlockOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(ib)
+	fixExtensionIndex(&v)
+	return v
+
+}
+
+func (ib RepositoryUnlockOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
+func (o RepositoryUnlockOptions) WithObjects(objectsFile string) RepositoryUnlockOptions {
+
+	newO := o
+	newO.Objects = objectsFile
+	return newO
+
+}
+
+func (o RepositoryUnlockOptions) WithRepository(repository Repository) RepositoryUnlockOptions {
+
+	newO := o
+	newO.Path = repository.Path
+	newO.User = repository.User
+	newO.Password = repository.Password
+	return newO
+
+}
+
+func (r Repository) Unlock(force ...bool) RepositoryUnlockOptions {
+
+	command := RepositoryUnlockOptions{
+		Designer:   NewDesigner(),
+		Repository: r,
+	}
+
+	if len(force) > 0 {
+		command.Force = force[0]
+	}
+
+	return command
+
+}
+
+///ConfigurationRepositoryCommit [-Extension <имя расширения>] [-objects <имя файла со списком объектов>]
+//[-comment "<текст комментария>"] [-keepLocked] [-force]
+//— помещение изменений объектов в хранилище конфигурации.
+//
+//-Extension <имя расширения> — Имя расширения. Если параметр не указан,
+// выполняется попытка соединения с хранилищем основной конфигурации, и команда выполняется для основной конфигурации.
+// Если параметр указан, выполняется попытка соединения с хранилищем указанного расширения, и команда выполняется для этого хранилища.
+//
+//-objects <имя файла со списком объектов> — путь к файлу формата XML со списком объектов.
+//Если параметр используется, будет выполнена попытка поместить только объекты, указанные в файле.
+//Если параметр не используется, будут помещены изменения всей конфигурации.
+//
+//-comment "<текст комментария>" — текст комментария к помещаемым объектам.
+//Для установки многострочного комментария для каждой строки следует использовать свой параметр -comment.
+//
+//-keepLocked — оставлять захват для помещенных объектов.
+//
+//-force — если при помещении объектов будут обнаружены ссылки на удаленные объекты,
+//будет выполнена попытка их очистить. Если параметр не указан, помещение выполнено не будет.
+type RepositoryCommitOptions struct {
+	Designer   `v8:",inherit" json:"designer"`
+	Repository `v8:",inherit" json:"repository"`
+
+	command struct{} `v8:"/ConfigurationRepositoryCommit" json:"-"`
+
+	//-objects <имя файла со списком объектов> — путь к файлу формата XML со списком объектов.
+	//Если параметр используется, будет выполнена попытка поместить только объекты, указанные в файле.
+	//Если параметр не используется, будут помещены изменения всей конфигурации.
+	Objects string `v8:"-objects, optional" json:"objects"`
+
+	//-comment "<текст комментария>" — текст комментария к помещаемым объектам.
+	//Для установки многострочного комментария для каждой строки следует использовать свой параметр -comment.
+	Comment RepositoryComment `v8:"-comment, optional" json:"comment"`
+
+	//-keepLocked — оставлять захват для помещенных объектов.
+	KeepLocked bool `v8:"-keepLocked, optional" json:"keep_locked"`
+
+	//-force — если при помещении объектов будут обнаружены ссылки на удаленные объекты,
+	//будет выполнена попытка их очистить. Если параметр не указан, помещение выполнено не будет.
+	Force bool `v8:"-force, optional" json:"force"`
+}
+
+func (ib RepositoryCommitOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(ib)
+	v = appendRepeatedValues(v, ib)
+	fixExtensionIndex(&v)
+	return v
+
+}
+
+func (ib RepositoryCommitOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	return err.ErrorOrNil()
+
+}
+
+func (o RepositoryCommitOptions) WithObjects(objectsFile string) RepositoryCommitOptions {
+
+	newO := o
+	newO.Objects = objectsFile
+	return newO
+
+}
+
+func (o RepositoryCommitOptions) WithComment(comment string) RepositoryCommitOptions {
+
+	newO := o
+	newO.Comment = RepositoryComment(comment)
+	return newO
+
+}
+
+func (o RepositoryCommitOptions) WithRepository(repository Repository) RepositoryCommitOptions {
+
+	newO := o
+	newO.Path = repository.Path
+	newO.User = repository.User
+	newO.Password = repository.Password
+	return newO
+
+}
+
+func (r Repository) Commit(comment string, keepLockedAndForce ...bool) RepositoryCommitOptions {
+
+	command := RepositoryCommitOptions{
+		Designer:   NewDesigner(),
+		Repository: r,
+		Comment:    RepositoryComment(comment),
+	}
+
+	if len(keepLockedAndForce) > 0 {
+		command.KeepLocked = keepLockedAndForce[0]
+		if len(keepLockedAndForce) == 2 {
+			command.Force = keepLockedAndForce[1]
+		}
+	}
+
+	return command
+
+}
@@ -0,0 +1,198 @@
+package designer
+
+import (
+	"github.com/v8platform/designer/tests"
+	"github.com/v8platform/runner"
+	"reflect"
+	"testing"
+)
+
+func TestRepositoryLockOptions_Values(t *testing.T) {
+
+	repo := Repository{
+		Path:     "./repo",
+		User:     "admin",
+		Password: "pwd",
+	}
+
+	tests := []struct {
+		name string
+		cmd  RepositoryLockOptions
+		want []string
+	}{
+		{
+			"simple",
+			RepositoryLockOptions{Repository: repo},
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryP pwd",
+				"/ConfigurationRepositoryLock",
+			},
+		},
+		{
+			"objects",
+			RepositoryLockOptions{Repository: repo}.WithObjects("./objects.xml"),
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryP pwd",
+				"/ConfigurationRepositoryLock",
+				"-objects ./objects.xml",
+			},
+		},
+		{
+			"extension",
+			RepositoryLockOptions{
+				Repository: Repository{
+					Path:      "./repo",
+					User:      "admin",
+					Extension: "temp_ext",
+				},
+				Revised: true,
+			},
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryLock",
+				"-revised",
+				"-Extension temp_ext",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryUnlockOptions_Values(t *testing.T) {
+
+	repo := Repository{
+		Path: "./repo",
+		User: "admin",
+	}
+
+	tests := []struct {
+		name string
+		cmd  RepositoryUnlockOptions
+		want []string
+	}{
+		{
+			"simple",
+			RepositoryUnlockOptions{Repository: repo},
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryUnlock",
+			},
+		},
+		{
+			"force",
+			RepositoryUnlockOptions{Repository: repo, Force: true}.WithObjects("./objects.xml"),
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryUnlock",
+				"-objects ./objects.xml",
+				"-force",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryCommitOptions_Values(t *testing.T) {
+
+	repo := Repository{
+		Path: "./repo",
+		User: "admin",
+	}
+
+	tests := []struct {
+		name string
+		cmd  RepositoryCommitOptions
+		want []string
+	}{
+		{
+			"simple",
+			RepositoryCommitOptions{Repository: repo},
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryCommit",
+			},
+		},
+		{
+			"comment",
+			RepositoryCommitOptions{Repository: repo}.WithComment("fix \"bug\""),
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryCommit",
+				"-comment fix \"bug\"",
+			},
+		},
+		{
+			"multiline comment",
+			RepositoryCommitOptions{
+				Repository: repo,
+				Objects:    "./objects.xml",
+				Comment:    "first line\r\nsecond line",
+				KeepLocked: true,
+				Force:      true,
+			},
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositoryCommit",
+				"-objects ./objects.xml",
+				"-keepLocked",
+				"-force",
+				"-comment first line -comment second line",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_Commit(t *testing.T) {
+
+	repo := Repository{Path: "./repo"}
+
+	got := repo.Commit("comment", true, true)
+
+	if got.Comment != "comment" || !got.KeepLocked || !got.Force {
+		t.Errorf("Commit() = %v", got)
+	}
+
+	if !reflect.DeepEqual(got.Designer, NewDesigner()) {
+		t.Errorf("Commit() designer = %v, want %v", got.Designer, NewDesigner())
+	}
+}
+
+func TestRepositoryCommitOptions_RunnerArgs(t *testing.T) {
+
+	what := Repository{Path: "./repo"}.Commit("line1\nline2", false, false)
+
+	args := runner.NewPlatformRunner(tests.NewFileIB("./ib"), what).Args()
+
+	if !containsFold(args, "-comment line1 -comment line2") {
+		t.Errorf("Args() = %v, want all comment lines", args)
+	}
+}
@@ -1,7 +1,10 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
+	"strings"
 )
 
 type GroupByType string
@@ -55,6 +58,28 @@ func (ib RepositoryReportOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: файл отчета обязателен,
+// начальная версия -NBegin не больше конечной -NEnd
+func (ib RepositoryReportOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	if len(ib.File) == 0 {
+		multierror.Append(&err, errors.Check.New("report file must be set").
+			WithContext("msg", "field File not set"))
+	}
+
+	if ib.NBegin > 0 && ib.NEnd > 0 && ib.NBegin > ib.NEnd {
+		multierror.Append(&err, errors.Check.Newf("begin version %d greater than end version %d", ib.NBegin, ib.NEnd).
+			WithContext("msg", "field NBegin greater than NEnd"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryReportOptions) GroupByObject() RepositoryReportOptions {
 
 	newO := o
@@ -99,3 +124,87 @@ func (r Repository) Report(file string, startAndEndVersions ...int64) Repository
 	return command
 
 }
+
+///ConfigurationRepositorySetLabel [-Extension <имя расширения>] [-v <номер версии хранилища>]
+//-name <текст метки> [-comment <текст комментария метки>]
+//— установка метки на версию хранилища.
+//
+//Пример:
+//DESIGNER /F"D:\V8\Cfgs82\ИБ82" /ConfigurationRepositoryF "D:\V8\Cfgs82" /ConfigurationRepositoryN "Администратор"
+// /ConfigurationRepositorySetLabel -v 4 -name "Релиз 1.0" -comment "Первая строка" -comment "Вторая строка"
+type RepositorySetLabelOptions struct {
+	Designer   `v8:",inherit" json:"designer"`
+	Repository `v8:",inherit" json:"repository"`
+
+	command struct{} `v8:"/ConfigurationRepositorySetLabel" json:"-"`
+
+	//-v <номер версии хранилища> — номер версии хранилища, на которую устанавливается метка.
+	//Если номер версии не указан, или равен -1, метка будет установлена на последнюю версию.
+	Version int64 `v8:"-v, optional" json:"version"`
+
+	//-name <текст метки> — текст устанавливаемой метки.
+	Label string `v8:"-name" json:"name"`
+
+	//-comment <текст комментария метки> — текст комментария к устанавливаемой метке.
+	//Для установки многострочного комментария для каждой строки следует использовать свой параметр -comment.
+	Comment RepositoryComment `v8:"-comment, optional" json:"comment"`
+}
+
+func (ib RepositorySetLabelOptions) Values() []string {
+
+	v, _ := marshaler.Marshal(ib)
+	v = appendRepeatedValues(v, ib)
+	fixExtensionIndex(&v)
+	return v
+
+}
+
+// Check проверяет параметры команды: текст метки -name обязателен
+func (ib RepositorySetLabelOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	if len(strings.TrimSpace(ib.Label)) == 0 {
+		multierror.Append(&err, errors.Check.New("label must be set").
+			WithContext("msg", "field Label not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
+func (o RepositorySetLabelOptions) WithComment(comment string) RepositorySetLabelOptions {
+
+	newO := o
+	newO.Comment = RepositoryComment(comment)
+	return newO
+
+}
+
+func (o RepositorySetLabelOptions) WithRepository(repository Repository) RepositorySetLabelOptions {
+
+	newO := o
+	newO.Path = repository.Path
+	newO.User = repository.User
+	newO.Password = repository.Password
+	return newO
+
+}
+
+func (r Repository) SetLabel(label string, version ...int64) RepositorySetLabelOptions {
+
+	command := RepositorySetLabelOptions{
+		Designer:   NewDesigner(),
+		Repository: r,
+		Label:      label,
+	}
+
+	if len(version) > 0 {
+		command.Version = version[0]
+	}
+
+	return command
+
+}
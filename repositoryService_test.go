@@ -0,0 +1,85 @@
+package designer
+
+import (
+	"github.com/v8platform/designer/tests"
+	"github.com/v8platform/runner"
+	"reflect"
+	"testing"
+)
+
+func TestRepositorySetLabelOptions_Values(t *testing.T) {
+
+	repo := Repository{
+		Path: "./repo",
+		User: "admin",
+	}
+
+	tests := []struct {
+		name string
+		cmd  RepositorySetLabelOptions
+		want []string
+	}{
+		{
+			"simple",
+			RepositorySetLabelOptions{Repository: repo, Label: "v1.0"},
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositorySetLabel",
+				"-name v1.0",
+			},
+		},
+		{
+			"version and comment",
+			RepositorySetLabelOptions{Repository: repo, Label: "v1.0", Version: 4}.
+				WithComment("first line\nsecond line"),
+			[]string{
+				"/ConfigurationRepositoryF ./repo",
+				"/ConfigurationRepositoryN admin",
+				"/ConfigurationRepositorySetLabel",
+				"-v 4",
+				"-name v1.0",
+				"-comment first line -comment second line",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositorySetLabelOptions_Check(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		label   string
+		wantErr bool
+	}{
+		{"label", "v1.0", false},
+		{"empty", "", true},
+		{"spaces", "  ", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Repository{Path: "./repo"}.SetLabel(tt.label, 1).Check()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepositorySetLabelOptions_RunnerArgs(t *testing.T) {
+
+	what := Repository{Path: "./repo"}.SetLabel("rel", 4).WithComment("a\nb")
+
+	args := runner.NewPlatformRunner(tests.NewFileIB("./ib"), what).Args()
+
+	if !containsFold(args, "-comment a -comment b") {
+		t.Errorf("Args() = %v, want all comment lines", args)
+	}
+}
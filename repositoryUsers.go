@@ -1,6 +1,8 @@
 package designer
 
 import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
 )
 
@@ -41,6 +43,27 @@ func (o RepositoryAddUserOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: имя пользователя -User и права -Rights обязательны
+func (o RepositoryAddUserOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check(), o.Repository.Check())
+
+	if len(o.NewUser) == 0 {
+		multierror.Append(&err, errors.Check.New("new user must be set").
+			WithContext("msg", "field NewUser not set"))
+	}
+
+	if len(o.Rights) == 0 {
+		multierror.Append(&err, errors.Check.New("user rights must be set").
+			WithContext("msg", "field Rights not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryAddUserOptions) WithRepository(repository Repository) RepositoryAddUserOptions {
 
 	newO := o
@@ -99,6 +122,27 @@ func (ib RepositoryCopyUsersOptions) Values() []string {
 
 }
 
+// Check проверяет параметры команды: каталог -Path и пользователь -User хранилища-источника обязательны
+func (ib RepositoryCopyUsersOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, ib.Designer.Check(), ib.Repository.Check())
+
+	if len(ib.RemotePath) == 0 {
+		multierror.Append(&err, errors.Check.New("remote repository path must be set").
+			WithContext("msg", "field RemotePath not set"))
+	}
+
+	if len(ib.RemoteUser) == 0 {
+		multierror.Append(&err, errors.Check.New("remote repository user must be set").
+			WithContext("msg", "field RemoteUser not set"))
+	}
+
+	return err.ErrorOrNil()
+
+}
+
 func (o RepositoryCopyUsersOptions) WithRepository(repository Repository) RepositoryCopyUsersOptions {
 
 	newO := o
@@ -12,14 +12,6 @@ import (
 	"testing"
 )
 
-type TempInfobase struct {
-	File string
-}
-
-func (ib TempInfobase) ConnectionString() string {
-	return "/IBConnectionString File=" + ib.File
-}
-
 type RepositoryTestSuite struct {
 	suite.Suite
 }
@@ -39,7 +31,7 @@ func (t *RepositoryTestSuite) TestCreateRepository() {
 	}
 
 	tempDir, _ := ioutil.TempDir("", "v8_temp_ib")
-	where := TempInfobase{tempDir}
+	where := NewFileInfobase(tempDir)
 	confFile := filepath.Join("tests", "fixtures", "0.9", "1Cv8.cf")
 
 	err := runner.Run(nil, CreateFileInfoBaseOptions{File: tempDir})
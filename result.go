@@ -0,0 +1,228 @@
+package designer
+
+import (
+	"bytes"
+	"github.com/v8platform/errors"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DUMP_RESULT_UNKNOWN код результата не получен (файл /DumpResult не указан, отсутствует или пуст)
+	DUMP_RESULT_UNKNOWN = -1
+
+	// DUMP_RESULT_SUCCESS команда выполнена успешно
+	DUMP_RESULT_SUCCESS = 0
+
+	// DUMP_RESULT_ERROR команда выполнена с ошибкой
+	DUMP_RESULT_ERROR = 1
+
+	// DUMP_RESULT_DATA_ERROR при выполнении команды обнаружены ошибки в данных
+	DUMP_RESULT_DATA_ERROR = 101
+)
+
+// Result результат выполнения конфигуратора, прочитанный из файлов /Out и /DumpResult
+type Result struct {
+	// Code код результата из файла /DumpResult или DUMP_RESULT_UNKNOWN
+	Code int `json:"code"`
+
+	// Out содержимое файла /Out
+	Out string `json:"out"`
+
+	// Messages непустые строки файла /Out
+	Messages []string `json:"messages"`
+}
+
+// ReadResult читает файлы /Out и /DumpResult после выполнения конфигуратора.
+// Пустое имя файла или отсутствующий файл не являются ошибкой.
+// Файлы декодируются из UTF-8 (с BOM или без), UTF-16 с BOM или windows-1251.
+func ReadResult(out, dumpResult string) (Result, error) {
+
+	result := Result{Code: DUMP_RESULT_UNKNOWN}
+
+	outText, err := readResultFile(out)
+
+	if err != nil {
+		return Result{}, errors.IO.Wrapf(err, "read out file <%s>", out)
+	}
+
+	result.Out = outText
+	result.Messages = resultMessages(outText)
+
+	codeText, err := readResultFile(dumpResult)
+
+	if err != nil {
+		return Result{}, errors.IO.Wrapf(err, "read dump result file <%s>", dumpResult)
+	}
+
+	if codeText = strings.TrimSpace(codeText); len(codeText) > 0 {
+
+		code, err := strconv.Atoi(codeText)
+
+		if err != nil {
+			return Result{}, errors.Invalid.Newf("invalid dump result <%s>", codeText)
+		}
+
+		result.Code = code
+
+	}
+
+	return result, nil
+}
+
+// Success команда выполнена успешно
+func (r Result) Success() bool {
+	return r.Code == DUMP_RESULT_SUCCESS
+}
+
+// Err возвращает ошибку выполнения (errors.Error) с сообщениями конфигуратора и содержимым /Out в контексте.
+// Вид ошибки (errors.Kind) определяется по коду результата и тексту сообщений, см. ErrorKind.
+// Для известной ошибки конфигуратора возвращается *ClassifiedError, который проверяется
+// через errors.Is(err, ErrInfobaseLocked) и т.д. и разворачивается в errors.Error.
+// Если код результата успешный или не получен, возвращается nil
+func (r Result) Err() error {
+
+	if r.Code == DUMP_RESULT_SUCCESS || r.Code == DUMP_RESULT_UNKNOWN {
+		return nil
+	}
+
+	msg := strings.Join(r.Messages, "; ")
+
+	if len(msg) == 0 {
+		msg = "no designer messages"
+	}
+
+	err := resultKind(r.Code, r.Messages).
+		Newf("designer exit code %d: %s", r.Code, msg).
+		WithContext("out", r.Out)
+
+	for _, message := range r.Messages {
+		if known, ok := ClassifyMessage(message); ok {
+			return &ClassifiedError{Known: known, Message: message, Err: err}
+		}
+	}
+
+	return err
+}
+
+var resultKindPatterns = []struct {
+	kind     errors.Kind
+	patterns []string
+}{
+	{errors.Permission, []string{
+		"идентификация пользователя не выполнена",
+		"неправильное имя пользователя или пароль",
+		"недостаточно прав",
+		"user authentication failed",
+		"insufficient rights",
+	}},
+	{errors.BadConnectString, []string{
+		"неверная строка соединения",
+		"неверные параметры соединения",
+		"invalid connection string",
+	}},
+	{errors.BadCommand, []string{
+		"неверные или отсутствующие параметры",
+		"неизвестный параметр",
+		"invalid or missing parameters",
+		"unknown parameter",
+	}},
+	{errors.NotExist, []string{
+		"не обнаружен",
+		"не найден",
+		"не существует",
+		"not found",
+		"does not exist",
+	}},
+	{errors.Transient, []string{
+		"заблокирован",
+		"блокировк",
+		"locked",
+	}},
+}
+
+// resultKind классифицирует ошибку выполнения
+func resultKind(code int, messages []string) errors.Kind {
+
+	if code == DUMP_RESULT_DATA_ERROR {
+		return errors.Database
+	}
+
+	for _, message := range messages {
+		if known, ok := ClassifyMessage(message); ok {
+			return known.Kind()
+		}
+	}
+
+	text := strings.ToLower(strings.Join(messages, "\n"))
+
+	for _, p := range resultKindPatterns {
+		if containsAny(text, p.patterns) {
+			return p.kind
+		}
+	}
+
+	return errors.Runtime
+}
+
+func resultMessages(out string) []string {
+
+	var messages []string
+
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			messages = append(messages, line)
+		}
+	}
+
+	return messages
+}
+
+func readResultFile(file string) (string, error) {
+
+	if len(file) == 0 {
+		return "", nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return decodeV8Text(data), nil
+}
+
+// decodeV8Text декодирует текстовые файлы платформы:
+// UTF-8 с BOM, UTF-16 с BOM, UTF-8 без BOM, иначе windows-1251
+func decodeV8Text(data []byte) string {
+
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:])
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		decoded, err := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+		if err == nil {
+			return string(decoded)
+		}
+	case utf8.Valid(data):
+		return string(data)
+	}
+
+	decoded, err := charmap.Windows1251.NewDecoder().Bytes(data)
+
+	if err != nil {
+		return string(data)
+	}
+
+	return string(decoded)
+}
@@ -0,0 +1,192 @@
+package designer
+
+import (
+	stderrors "errors"
+	"github.com/v8platform/designer/tests"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDesigner_RunOptions(t *testing.T) {
+
+	d := NewDesigner()
+	d.Out = "./out.txt"
+	d.NoTruncate = true
+	d.DumpResult = "./result.txt"
+	d.AccessCode = "123"
+
+	want := []string{
+		"/DisableStartupDialogs",
+		"/DisableStartupMessages",
+	}
+
+	if got := d.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+
+	what := DumpIBOptions{Designer: d, File: "./1.dt"}
+	args := runner.NewPlatformRunner(tests.NewFileIB("./ib"), what, what.RunOptions()...).Args()
+
+	var out, dumpResult []string
+
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "/Out"):
+			out = append(out, arg)
+		case strings.HasPrefix(arg, "/DumpResult"):
+			dumpResult = append(dumpResult, arg)
+		}
+	}
+
+	if want := []string{"/Out ./out.txt -NoTruncate"}; !reflect.DeepEqual(out, want) {
+		t.Errorf("Args() /Out = %v, want %v", out, want)
+	}
+
+	if want := []string{"/DumpResult ./result.txt"}; !reflect.DeepEqual(dumpResult, want) {
+		t.Errorf("Args() /DumpResult = %v, want %v", dumpResult, want)
+	}
+
+	if !containsFold(args, "/UC 123") {
+		t.Errorf("Args() = %v, want /UC 123", args)
+	}
+
+	d.Out = ""
+
+	if err := d.Check(); err == nil {
+		t.Errorf("Check() error = nil, want error for NoTruncate without Out")
+	}
+
+}
+
+func TestReadResult(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "v8_result_")
+	defer os.RemoveAll(dir)
+
+	cp1251, _ := charmap.Windows1251.NewEncoder().Bytes([]byte("Ошибка в данных\r\nКонфигурация не найдена\r\n"))
+	utf16, _ := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().Bytes([]byte("Информационная база заблокирована"))
+
+	write := func(name string, data []byte) string {
+		file := filepath.Join(dir, name)
+		_ = ioutil.WriteFile(file, data, 0644)
+		return file
+	}
+
+	tests := []struct {
+		name       string
+		out        []byte
+		dumpResult []byte
+		want       Result
+		wantKind   errors.Kind
+		wantErr    bool
+		wantIs     error
+	}{
+		{
+			"success utf-8 bom",
+			[]byte("\ufeffОбновление конфигурации успешно завершено\r\n"),
+			[]byte("0"),
+			Result{
+				Code:     DUMP_RESULT_SUCCESS,
+				Out:      "Обновление конфигурации успешно завершено\r\n",
+				Messages: []string{"Обновление конфигурации успешно завершено"},
+			},
+			errors.Other,
+			false,
+			nil,
+		},
+		{
+			"not exist cp1251",
+			cp1251,
+			[]byte("1\r\n"),
+			Result{
+				Code:     DUMP_RESULT_ERROR,
+				Out:      "Ошибка в данных\r\nКонфигурация не найдена\r\n",
+				Messages: []string{"Ошибка в данных", "Конфигурация не найдена"},
+			},
+			errors.NotExist,
+			true,
+			nil,
+		},
+		{
+			"locked utf-16",
+			utf16,
+			[]byte("1"),
+			Result{
+				Code:     DUMP_RESULT_ERROR,
+				Out:      "Информационная база заблокирована",
+				Messages: []string{"Информационная база заблокирована"},
+			},
+			errors.Transient,
+			true,
+			ErrInfobaseLocked,
+		},
+		{
+			"data error",
+			nil,
+			[]byte("101"),
+			Result{Code: DUMP_RESULT_DATA_ERROR},
+			errors.Database,
+			true,
+			nil,
+		},
+		{
+			"unknown",
+			[]byte("Неизвестная ошибка"),
+			nil,
+			Result{
+				Code:     DUMP_RESULT_UNKNOWN,
+				Out:      "Неизвестная ошибка",
+				Messages: []string{"Неизвестная ошибка"},
+			},
+			errors.Other,
+			false,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var out, dumpResult string
+
+			if tt.out != nil {
+				out = write("out.txt", tt.out)
+			}
+
+			if tt.dumpResult != nil {
+				dumpResult = write("result.txt", tt.dumpResult)
+			}
+
+			defer os.Remove(filepath.Join(dir, "out.txt"))
+			defer os.Remove(filepath.Join(dir, "result.txt"))
+
+			got, err := ReadResult(out, dumpResult)
+			if err != nil {
+				t.Errorf("ReadResult() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadResult() = %#v, want %#v", got, tt.want)
+			}
+
+			err = got.Err()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Err() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if kind := ErrorKind(err); kind != tt.wantKind {
+				t.Errorf("Err() kind = %v, want %v", kind, tt.wantKind)
+			}
+			if tt.wantIs != nil && !stderrors.Is(err, tt.wantIs) {
+				t.Errorf("Err() = %v, want errors.Is %v", err, tt.wantIs)
+			}
+		})
+	}
+}
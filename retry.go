@@ -0,0 +1,328 @@
+package designer
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"github.com/v8platform/errors"
+	"github.com/v8platform/runner"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// jitterRand источник случайного отклонения задержки, не зависящий от глобального math/rand
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	jitterMu   sync.Mutex
+)
+
+// RunFunc запускает команду конфигуратора.
+// По умолчанию используется runner.NewPlatformRunner(where, what, opts...).Run(ctx)
+type RunFunc func(ctx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error
+
+// RetryPolicy политика повторного запуска команд при временных ошибках
+// (блокировка информационной базы, захват объектов хранилища другим пользователем и т.д.)
+type RetryPolicy struct {
+	// MaxAttempts максимальное количество запусков, включая первый
+	MaxAttempts int `json:"max_attempts"`
+
+	// InitialDelay задержка перед вторым запуском
+	InitialDelay time.Duration `json:"initial_delay"`
+
+	// MaxDelay максимальная задержка между запусками
+	MaxDelay time.Duration `json:"max_delay"`
+
+	// Multiplier множитель задержки для каждого следующего запуска, значения меньше 1 считаются равными 1
+	Multiplier float64 `json:"multiplier"`
+
+	// Jitter доля случайного отклонения задержки (0..1), значения вне диапазона ограничиваются
+	Jitter float64 `json:"jitter"`
+
+	// Retryable определяет, нужен ли повторный запуск после ошибки. По умолчанию IsRetryable
+	Retryable func(err error) bool `json:"-"`
+
+	// Run функция запуска команды. По умолчанию запуск через runner
+	Run RunFunc `json:"-"`
+}
+
+// RetryAttempt запуск команды в рамках политики повтора
+type RetryAttempt struct {
+	// Number номер запуска, начиная с 1
+	Number int `json:"number"`
+
+	// Err ошибка запуска, классифицированная по сообщениям конфигуратора
+	Err error `json:"-"`
+
+	// Out содержимое файла /Out
+	Out string `json:"out"`
+
+	// Delay задержка перед следующим запуском
+	Delay time.Duration `json:"delay"`
+}
+
+// RetryError ошибка выполнения команды после всех запусков
+type RetryError struct {
+	Attempts []RetryAttempt `json:"attempts"`
+
+	// Err ошибка последнего запуска или ошибка контекста
+	Err error `json:"-"`
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("designer command failed after %d attempt(s): %v", len(e.Attempts), e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// NewRetryPolicy создает политику повтора по умолчанию:
+// 5 запусков, задержка от 10 секунд до 2 минут с удвоением и отклонением 20%
+func NewRetryPolicy() RetryPolicy {
+
+	return RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: 10 * time.Second,
+		MaxDelay:     2 * time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// IsRetryable ошибка вызвана временной блокировкой информационной базы или хранилища
+func IsRetryable(err error) bool {
+
+	return stderrors.Is(err, ErrInfobaseLocked) ||
+		stderrors.Is(err, ErrObjectLocked) ||
+		stderrors.Is(err, ErrRepositoryLocked)
+}
+
+// Delay возвращает задержку перед запуском с номером attempt+1 без учета случайного отклонения
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+
+	delay := float64(p.InitialDelay)
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			return p.MaxDelay
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+
+	return time.Duration(delay)
+}
+
+// Do выполняет команду what в информационной базе where, повторяя запуск при временных ошибках.
+// Для каждого запуска создаются отдельные файлы /Out и /DumpResult, содержимое /Out сохраняется
+// в RetryError. Повторы прекращаются по исчерпании MaxAttempts, при невосстановимой ошибке
+// или если следующий запуск не успевает до завершения контекста ctx
+func (p RetryPolicy) Do(ctx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error {
+
+	if err := what.Check(); err != nil {
+		return err
+	}
+
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var attempts []RetryAttempt
+
+	for number := 1; ; number++ {
+
+		attempt, err := p.attempt(ctx, number, where, what, opts)
+
+		if err != nil {
+			return err
+		}
+
+		if attempt.Err == nil {
+			return nil
+		}
+
+		fail := func(err error) error {
+			return &RetryError{Attempts: append(attempts, attempt), Err: err}
+		}
+
+		if number >= maxAttempts || !retryable(attempt.Err) {
+			return fail(attempt.Err)
+		}
+
+		attempt.Delay = p.jitter(p.Delay(number))
+
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(attempt.Delay).After(deadline) {
+			return fail(attempt.Err)
+		}
+
+		attempts = append(attempts, attempt)
+
+		timer := time.NewTimer(attempt.Delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: attempts, Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+	}
+
+}
+
+func (p RetryPolicy) attempt(ctx context.Context, number int,
+	where runner.Infobase, what runner.Command, opts []interface{}) (RetryAttempt, error) {
+
+	out, err := runCapture(ctx, p.Run, where, what, opts)
+
+	if err != nil {
+		return RetryAttempt{}, err
+	}
+
+	return RetryAttempt{
+		Number: number,
+		Err:    out.Err,
+		Out:    out.Out,
+	}, nil
+}
+
+// resultFiler команда с собственными файлами /Out и /DumpResult (см. Designer)
+type resultFiler interface {
+	resultFiles() (out string, noTruncate bool, dumpResult string)
+}
+
+// runOptioner команда с собственными опциями запуска (см. Designer.RunOptions)
+type runOptioner interface {
+	RunOptions() []interface{}
+}
+
+// capturedRun результат запуска команды с перехватом файлов /Out и /DumpResult
+type capturedRun struct {
+	Out string
+
+	// Err ошибка запуска, классифицированная по сообщениям конфигуратора
+	Err error
+}
+
+// runCapture запускает команду с файлами /Out и /DumpResult команды
+// или с временными файлами, если они не указаны.
+// Ошибка возвращается только если не удалось создать временные файлы,
+// ошибка выполнения команды возвращается в capturedRun.Err
+func runCapture(ctx context.Context, run RunFunc, where runner.Infobase, what runner.Command, opts []interface{}) (capturedRun, error) {
+
+	var out, dumpResult string
+	var noTruncate bool
+
+	if files, ok := what.(resultFiler); ok {
+		out, noTruncate, dumpResult = files.resultFiles()
+	}
+
+	if len(out) == 0 {
+
+		var err error
+
+		if out, err = tempResultFile("v8_out_*.txt"); err != nil {
+			return capturedRun{}, err
+		}
+		defer os.Remove(out)
+
+		noTruncate = false
+	}
+
+	if len(dumpResult) == 0 {
+
+		var err error
+
+		if dumpResult, err = tempResultFile("v8_dump_result_*.txt"); err != nil {
+			return capturedRun{}, err
+		}
+		defer os.Remove(dumpResult)
+	}
+
+	if run == nil {
+		run = runPlatform
+	}
+
+	runOpts := append([]interface{}{}, opts...)
+
+	if o, ok := what.(runOptioner); ok {
+		runOpts = append(runOpts, o.RunOptions()...)
+	}
+
+	runOpts = append(runOpts,
+		runner.WithOut(out, noTruncate),
+		runner.WithDumpResult(dumpResult),
+	)
+
+	runErr := run(ctx, where, what, runOpts...)
+
+	result, _ := ReadResult(out, dumpResult)
+
+	if runErr == nil {
+		runErr = result.Err()
+	}
+
+	return capturedRun{
+		Out: result.Out,
+		Err: Classify(runErr, result.Messages...),
+	}, nil
+}
+
+func (p RetryPolicy) jitter(delay time.Duration) time.Duration {
+
+	jitter := p.Jitter
+
+	if jitter > 1 {
+		jitter = 1
+	}
+
+	if jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	jitterMu.Lock()
+	r := jitterRand.Float64()
+	jitterMu.Unlock()
+
+	delay += time.Duration(float64(delay) * jitter * (2*r - 1))
+
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
+
+func runPlatform(ctx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error {
+	return runner.NewPlatformRunner(where, what, opts...).Run(ctx)
+}
+
+func tempResultFile(pattern string) (string, error) {
+
+	f, err := ioutil.TempFile("", pattern)
+
+	if err != nil {
+		return "", errors.Internal.Wrapf(err, "create temp file")
+	}
+
+	_ = f.Close()
+
+	return f.Name(), nil
+}
@@ -0,0 +1,186 @@
+package designer
+
+import (
+	"context"
+	stderrors "errors"
+	"github.com/v8platform/runner"
+	"io/ioutil"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeRun возвращает функцию запуска, которая записывает в файлы /Out и /DumpResult
+// очередной результат из списка
+func fakeRun(calls *int, results ...Result) RunFunc {
+
+	return func(ctx context.Context, where runner.Infobase, what runner.Command, opts ...interface{}) error {
+
+		options := runner.Options{}
+
+		for _, opt := range opts {
+			if fn, ok := opt.(runner.Option); ok {
+				fn(&options)
+			}
+		}
+
+		result := results[*calls]
+		*calls++
+
+		_ = ioutil.WriteFile(options.Out, []byte(result.Out), 0644)
+		_ = ioutil.WriteFile(options.DumpResult, []byte(strconv.Itoa(result.Code)), 0644)
+
+		return nil
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+
+	locked := Result{Code: DUMP_RESULT_ERROR, Out: "Ошибка блокировки информационной базы для конфигурирования"}
+	auth := Result{Code: DUMP_RESULT_ERROR, Out: "Идентификация пользователя не выполнена"}
+	success := Result{Code: DUMP_RESULT_SUCCESS}
+
+	policy := RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		Multiplier:   2,
+	}
+
+	tests := []struct {
+		name      string
+		ctx       func() (context.Context, context.CancelFunc)
+		results   []Result
+		wantCalls int
+		wantErr   error
+	}{
+		{"success after lock", nil, []Result{locked, locked, success}, 3, nil},
+		{"not retryable", nil, []Result{auth, success}, 1, ErrInfobaseAuth},
+		{"attempts exhausted", nil, []Result{locked, locked, locked, success}, 3, ErrInfobaseLocked},
+		{
+			"deadline",
+			func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Microsecond)
+			},
+			[]Result{locked, success},
+			1,
+			ErrInfobaseLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctx := context.Background()
+
+			if tt.ctx != nil {
+				var cancel context.CancelFunc
+				ctx, cancel = tt.ctx()
+				defer cancel()
+			}
+
+			calls := 0
+			p := policy
+			p.Run = fakeRun(&calls, tt.results...)
+
+			err := p.Do(ctx, NewFileInfobase("./ib"), NewDesigner())
+
+			if calls != tt.wantCalls {
+				t.Errorf("Do() calls = %v, want %v", calls, tt.wantCalls)
+			}
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Do() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var retryErr *RetryError
+
+			if !stderrors.As(err, &retryErr) {
+				t.Fatalf("Do() error = %T, want *RetryError", err)
+			}
+
+			if len(retryErr.Attempts) != tt.wantCalls {
+				t.Errorf("Attempts = %v, want %v", len(retryErr.Attempts), tt.wantCalls)
+			}
+
+			for i, attempt := range retryErr.Attempts {
+				if attempt.Number != i+1 || attempt.Out != tt.results[i].Out {
+					t.Errorf("Attempts[%d] = %+v, want out %q", i, attempt, tt.results[i].Out)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+
+	p := RetryPolicy{
+		InitialDelay: time.Second,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{10, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.Delay(tt.attempt); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_DelayMultiplier(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		multiplier float64
+	}{
+		{"zero", 0},
+		{"negative", -2},
+		{"less than one", 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Second, Multiplier: tt.multiplier}
+
+			if got := p.Delay(3); got != time.Second {
+				t.Errorf("Delay(3) = %v, want %v", got, time.Second)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_jitter(t *testing.T) {
+
+	tests := []struct {
+		jitter   float64
+		min, max time.Duration
+	}{
+		{0, time.Second, time.Second},
+		{-1, time.Second, time.Second},
+		{0.5, 500 * time.Millisecond, 1500 * time.Millisecond},
+		{5, 0, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		p := RetryPolicy{Jitter: tt.jitter}
+		for i := 0; i < 100; i++ {
+			if got := p.jitter(time.Second); got < tt.min || got > tt.max {
+				t.Fatalf("jitter(%v) with Jitter %v = %v, want in [%v, %v]", time.Second, tt.jitter, got, tt.min, tt.max)
+			}
+		}
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/v8platform/errors"
 	"github.com/v8platform/marshaler"
+	"time"
 )
 
+// REDUCE_EVENT_LOG_DATE_FORMAT формат границы журнала регистрации (ГГГГ-ММ-ДД)
+const REDUCE_EVENT_LOG_DATE_FORMAT = "2006-01-02"
+
 ///IBRestoreIntegrity
 //— восстановление структуры информационной базы.
 //При использовании данного ключа запуска, остальные ключи запуска будут проигнорированы:
@@ -28,6 +32,16 @@ func (d IBRestoreIntegrityOptions) Values() []string {
 
 }
 
+func (d IBRestoreIntegrityOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 ///RollbackCfg [-Extension <имя расширения>]
 //— возврат к конфигурации базы данных. Доступные параметры:
 type RollbackCfgOptions struct {
@@ -45,6 +59,16 @@ func (d RollbackCfgOptions) Values() []string {
 
 }
 
+func (d RollbackCfgOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, d.Designer.Check())
+
+	return err.ErrorOrNil()
+
+}
+
 func (d RollbackCfgOptions) WithExtension(extension string) RollbackCfgOptions {
 
 	return RollbackCfgOptions{
@@ -75,10 +99,14 @@ func (o ManageCfgSupportOptions) Values() []string {
 	return v
 
 }
+
+// Check проверяет параметры команды: -disableSupport обязателен
 func (o ManageCfgSupportOptions) Check() error {
 
 	var err multierror.Error
 
+	multierror.Append(&err, o.Designer.Check())
+
 	if !o.DisableSupport {
 		multierror.Append(&err, errors.Check.New("disable support must be set"))
 	}
@@ -106,3 +134,22 @@ func (o ReduceEventLogSizeOptions) Values() []string {
 	return v
 
 }
+
+// Check проверяет параметры команды: граница журнала обязательна и указывается в формате ГГГГ-ММ-ДД
+func (o ReduceEventLogSizeOptions) Check() error {
+
+	var err multierror.Error
+
+	multierror.Append(&err, o.Designer.Check())
+
+	if len(o.Date) == 0 {
+		multierror.Append(&err, errors.Check.New("event log date must be set").
+			WithContext("msg", "field Date not set"))
+	} else if _, e := time.Parse(REDUCE_EVENT_LOG_DATE_FORMAT, o.Date); e != nil {
+		multierror.Append(&err, errors.Check.Newf("event log date <%s> must be in format YYYY-MM-DD", o.Date).
+			WithContext("msg", "field Date has invalid format"))
+	}
+
+	return err.ErrorOrNil()
+
+}
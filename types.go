@@ -1,14 +1,72 @@
 package designer
 
+import (
+	"github.com/v8platform/marshaler"
+	"reflect"
+	"strings"
+)
+
 type command interface {
 	Command() string
 	Check() error
 	Values() []string
 }
 
+// repeatedValue значение, ключ которого повторяется для каждого элемента,
+// например -comment для каждой строки комментария или -f для каждого файла.
+// Такие значения не реализуют MarshalV8 и добавляются к параметрам команды appendRepeatedValues
+type repeatedValue interface {
+	items() []string
+}
+
 const (
 	COMMAND_DESIGNER             = "DESIGNER"
 	COMMAND_CREATEINFOBASE       = "CREATEINFOBASE"
 	COMMAND_ENTERPRISE           = "ENTERPRISE"
 	DEFAULT_1SSERVER_PORT  int16 = 1541
 )
+
+// appendRepeatedValues добавляет к параметрам команды значения полей repeatedValue:
+// ключ из тега v8 и значение для каждого элемента одним параметром (-f a.cf -f b.cf).
+// Отдельные параметры с одинаковым ключом runner заменяет последним из них
+func appendRepeatedValues(values []string, object interface{}) []string {
+
+	v := reflect.Indirect(reflect.ValueOf(object))
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+
+		info := marshaler.GetFieldTagInfo(t.Field(i))
+		field := v.Field(i)
+
+		if info == nil || !field.CanInterface() {
+			continue
+		}
+
+		if info.Inherit {
+			if field.Kind() == reflect.Struct {
+				values = appendRepeatedValues(values, field.Interface())
+			}
+			continue
+		}
+
+		repeated, ok := field.Interface().(repeatedValue)
+
+		if !ok {
+			continue
+		}
+
+		var parts []string
+
+		for _, item := range repeated.items() {
+			parts = append(parts, info.Name+info.Sep+item)
+		}
+
+		if len(parts) > 0 {
+			values = append(values, strings.Join(parts, " "))
+		}
+
+	}
+
+	return values
+}